@@ -0,0 +1,256 @@
+package logAdmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LevelController is a Logger capability to read and change its level at runtime
+type LevelController interface {
+	GetLevel() level.LogLevel
+	SetLevel(lv level.LogLevel)
+}
+
+// NamespaceLevelController is a Logger capability to read and change level of each namespace at runtime
+type NamespaceLevelController interface {
+	NamespaceLevels() map[string]level.LogLevel
+	NamespaceLevel(namespace string) (level.LogLevel, bool)
+	SetNamespaceLevel(namespace string, lv level.LogLevel)
+	ResetNamespaceLevel(namespace string)
+}
+
+// State is the response body of Handler
+type State struct {
+	Level string `json:"level"`
+	// Namespaces contains level of each namespace that has level override
+	Namespaces map[string]string `json:"namespaces"`
+}
+
+// Request is the request body to change level. Level accepts the same value as level.Parse.
+// If Namespace is empty, then level of the logger will be changed. If TTL is set, then level will be reverted
+// after the duration has passed
+type Request struct {
+	Level     string `json:"level"`
+	Namespace string `json:"namespace"`
+	TTL       string `json:"ttl"`
+}
+
+// NewHandler creates a http.Handler to view and change level of a Logger.
+// If logger is nil, then registered logger will be retrieved on each request
+func NewHandler(logger nlogger.Logger) *Handler {
+	return &Handler{
+		logger:  logger,
+		reverts: make(map[string]*pendingRevert),
+	}
+}
+
+// Handler serves GET to retrieve current levels, PUT and POST to change level
+type Handler struct {
+	logger  nlogger.Logger
+	mu      sync.Mutex
+	reverts map[string]*pendingRevert
+}
+
+// pendingRevert holds state before level is changed temporarily
+type pendingRevert struct {
+	timer      *time.Timer
+	level      level.LogLevel
+	overridden bool
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Get logger
+	l := h.logger
+	if l == nil {
+		l = nlogger.Get()
+	}
+
+	// Get logger implementation, since registered logger is wrapped
+	c, ok := nlogger.Unwrap(l).(LevelController)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, "logger does not support changing level at runtime")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeState(w, c)
+	case http.MethodPut, http.MethodPost:
+		h.change(w, r, c)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *Handler) change(w http.ResponseWriter, r *http.Request, c LevelController) {
+	// Parse request
+	req, err := parseRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lv, ok := level.TryParse(req.Level)
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid level %q", req.Level))
+		return
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		ttl, err = time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid ttl %q", req.TTL))
+			return
+		}
+	}
+
+	// Resolve level target
+	var target levelTarget
+	if req.Namespace == "" {
+		target = rootTarget{c}
+	} else {
+		nc, ok := c.(NamespaceLevelController)
+		if !ok {
+			writeError(w, http.StatusNotImplemented, "logger does not support namespace level")
+			return
+		}
+		target = namespaceTarget{c: nc, namespace: req.Namespace}
+	}
+
+	h.set(req.Namespace, target, lv, ttl)
+	writeState(w, c)
+}
+
+// set change level of target. If ttl is set, then schedule revert to the state before the first temporary change
+func (h *Handler) set(key string, target levelTarget, lv level.LogLevel, ttl time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Cancel pending revert, but keep the original state
+	pending, ok := h.reverts[key]
+	if ok {
+		pending.timer.Stop()
+		delete(h.reverts, key)
+	} else if ttl > 0 {
+		pending = &pendingRevert{}
+		pending.level, pending.overridden = target.get()
+	}
+
+	target.set(lv)
+
+	if ttl <= 0 {
+		return
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		// Skip if revert has been replaced
+		if p, ok := h.reverts[key]; !ok || p.timer != timer {
+			return
+		}
+		delete(h.reverts, key)
+		target.restore(pending.level, pending.overridden)
+	})
+	pending.timer = timer
+	h.reverts[key] = pending
+}
+
+type levelTarget interface {
+	get() (level.LogLevel, bool)
+	set(lv level.LogLevel)
+	restore(lv level.LogLevel, overridden bool)
+}
+
+type rootTarget struct {
+	c LevelController
+}
+
+func (t rootTarget) get() (level.LogLevel, bool) {
+	return t.c.GetLevel(), true
+}
+
+func (t rootTarget) set(lv level.LogLevel) {
+	t.c.SetLevel(lv)
+}
+
+func (t rootTarget) restore(lv level.LogLevel, _ bool) {
+	t.c.SetLevel(lv)
+}
+
+type namespaceTarget struct {
+	c         NamespaceLevelController
+	namespace string
+}
+
+func (t namespaceTarget) get() (level.LogLevel, bool) {
+	return t.c.NamespaceLevel(t.namespace)
+}
+
+func (t namespaceTarget) set(lv level.LogLevel) {
+	t.c.SetNamespaceLevel(t.namespace, lv)
+}
+
+func (t namespaceTarget) restore(lv level.LogLevel, overridden bool) {
+	if !overridden {
+		t.c.ResetNamespaceLevel(t.namespace)
+		return
+	}
+	t.c.SetNamespaceLevel(t.namespace, lv)
+}
+
+// parseRequest parse request from json body, or from query and form values
+func parseRequest(r *http.Request) (*Request, error) {
+	var req Request
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, fmt.Errorf("invalid request body: %s", err)
+		}
+		return &req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("invalid request form: %s", err)
+	}
+	req.Level = r.Form.Get("level")
+	req.Namespace = r.Form.Get("namespace")
+	req.TTL = r.Form.Get("ttl")
+	return &req, nil
+}
+
+func writeState(w http.ResponseWriter, c LevelController) {
+	state := State{
+		Level:      level.String(c.GetLevel()),
+		Namespaces: make(map[string]string),
+	}
+
+	if nc, ok := c.(NamespaceLevelController); ok {
+		for ns, lv := range nc.NamespaceLevels() {
+			state.Namespaces[ns] = level.String(lv)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,103 @@
+package nlogger_test
+
+import (
+	"encoding/json"
+	"github.com/nbs-go/nlogger/v2"
+	logAdmin "github.com/nbs-go/nlogger/v2/admin"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAdminHandler(t *testing.T) {
+	root := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0), logOption.Level(level.Info))
+	child := root.NewChild(logOption.WithNamespace("db"))
+	h := logAdmin.NewHandler(root)
+
+	// Get current state, namespaces without override are not listed
+	state := serveAdmin(t, h, http.MethodGet, "/", "", "", http.StatusOK)
+	if state.Level != "Info" || len(state.Namespaces) != 0 {
+		t.Errorf("unexpected state = %+v", state)
+	}
+
+	// Change namespace level with form values
+	state = serveAdmin(t, h, http.MethodPut, "/", "application/x-www-form-urlencoded",
+		"level=trace&namespace=db", http.StatusOK)
+	if state.Level != "Info" || state.Namespaces["db"] != "Trace" {
+		t.Errorf("unexpected state = %+v", state)
+	}
+
+	if lv := child.(*nlogger.StdLogger).GetLevel(); lv != level.Trace {
+		t.Errorf("unexpected child level = %d", lv)
+	}
+
+	// Change root level with json body
+	state = serveAdmin(t, h, http.MethodPost, "/", "application/json", `{"level":"debug"}`, http.StatusOK)
+	if state.Level != "Debug" || state.Namespaces["db"] != "Trace" {
+		t.Errorf("unexpected state = %+v", state)
+	}
+
+	// Invalid level
+	serveAdmin(t, h, http.MethodPut, "/?level=verbose", "", "", http.StatusBadRequest)
+
+	// Invalid method
+	serveAdmin(t, h, http.MethodDelete, "/", "", "", http.StatusMethodNotAllowed)
+}
+
+func TestAdminHandler_TTL(t *testing.T) {
+	root := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0), logOption.Level(level.Info))
+	child := root.NewChild(logOption.WithNamespace("db")).(*nlogger.StdLogger)
+	h := logAdmin.NewHandler(root)
+
+	serveAdmin(t, h, http.MethodPut, "/?level=trace&namespace=db&ttl=10ms", "", "", http.StatusOK)
+	serveAdmin(t, h, http.MethodPut, "/?level=debug&namespace=db&ttl=20ms", "", "", http.StatusOK)
+	if lv := child.GetLevel(); lv != level.Debug {
+		t.Errorf("unexpected child level = %d", lv)
+	}
+
+	// Wait until level is reverted to the state before the first change
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, overridden := root.NamespaceLevel("db"); !overridden {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, overridden := root.NamespaceLevel("db"); overridden {
+		t.Errorf("namespace level is not reverted")
+	}
+
+	root.SetLevel(level.Warn)
+	if lv := child.GetLevel(); lv != level.Warn {
+		t.Errorf("child level must inherit root level after reverted. Level = %d", lv)
+	}
+}
+
+func serveAdmin(t *testing.T, h http.Handler, method, target, contentType, body string, status int) logAdmin.State {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != status {
+		t.Fatalf("unexpected status code. Expected = %d, Actual = %d, Body = %s", status, rec.Code, rec.Body)
+	}
+
+	var state logAdmin.State
+	if status == http.StatusOK {
+		if err := json.NewDecoder(rec.Body).Decode(&state); err != nil {
+			t.Fatalf("unexpected error when parsing response. Error = %s", err)
+		}
+	}
+	return state
+}
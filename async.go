@@ -0,0 +1,308 @@
+package nlogger
+
+import (
+	"context"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"sync"
+	"time"
+)
+
+// OverflowPolicy defines how async printer handles new entry when queue is full
+type OverflowPolicy = int8
+
+// OverflowPolicy constants
+const (
+	// OverflowBlock blocks caller until queue has space
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the new entry
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest entry in queue to make space for the new entry
+	OverflowDropOldest
+	// OverflowDropBelowLevel drops the new entry if its level is less severe than drop level, otherwise blocks
+	OverflowDropBelowLevel
+)
+
+// DefaultAsyncQueueSize is the default number of entries that can be queued by async printer
+const DefaultAsyncQueueSize = 1024
+
+// AsyncOption is a function that set configuration of async printer
+type AsyncOption func(*asyncOptions)
+
+type asyncOptions struct {
+	queueSize int
+	overflow  OverflowPolicy
+	dropLevel level.LogLevel
+}
+
+// WithQueueSize set maximum number of queued entries
+func WithQueueSize(n int) AsyncOption {
+	return func(o *asyncOptions) {
+		if n > 0 {
+			o.queueSize = n
+		}
+	}
+}
+
+// WithOverflowPolicy set how new entry is handled when queue is full
+func WithOverflowPolicy(p OverflowPolicy) AsyncOption {
+	return func(o *asyncOptions) {
+		o.overflow = p
+	}
+}
+
+// WithDropLevel set OverflowDropBelowLevel policy. When queue is full, entries less severe than lv are dropped
+func WithDropLevel(lv level.LogLevel) AsyncOption {
+	return func(o *asyncOptions) {
+		o.overflow = OverflowDropBelowLevel
+		o.dropLevel = lv
+	}
+}
+
+// NewAsyncPrinter creates a Printer that queues entries and prints them to p in a background goroutine.
+// Entries keep the time when they are queued. Call Close to drain queue and stop the goroutine
+func NewAsyncPrinter(p Printer, args ...AsyncOption) *asyncPrinter {
+	o := asyncOptions{
+		queueSize: DefaultAsyncQueueSize,
+		overflow:  OverflowBlock,
+		dropLevel: level.Warn,
+	}
+	for _, fn := range args {
+		fn(&o)
+	}
+
+	a := asyncPrinter{
+		printer:  p,
+		options:  o,
+		queue:    make(chan asyncEntry, o.queueSize),
+		closing:  make(chan struct{}),
+		progress: make(chan struct{}),
+		exited:   make(chan struct{}),
+	}
+	go a.run()
+	return &a
+}
+
+type asyncEntry struct {
+	namespace string
+	level     level.LogLevel
+	msg       string
+	options   *logOption.Options
+}
+
+type asyncPrinter struct {
+	printer Printer
+	options asyncOptions
+	queue   chan asyncEntry
+	exited  chan struct{}
+
+	// closing is closed when Close is called, so blocked senders are released. sendMu guards queue from being
+	// closed while entries are sent
+	closing     chan struct{}
+	sendMu      sync.Mutex
+	senders     int
+	closed      bool
+	queueClosed bool
+
+	// mu guards counters. progress is closed and replaced each time entries are done
+	mu       sync.Mutex
+	queued   uint64
+	done     uint64
+	dropped  uint64
+	progress chan struct{}
+}
+
+func (a *asyncPrinter) Print(namespace string, outLevel level.LogLevel, msg string, options *logOption.Options) {
+	// Copy options, so queued entry does not share state with caller
+	options = copyOptions(options)
+
+	// Keep the time of entry
+	if _, ok := options.Values[logOption.TimestampKey]; !ok {
+		options.Values[logOption.TimestampKey] = time.Now()
+	}
+
+	a.enqueue(asyncEntry{namespace: namespace, level: outLevel, msg: msg, options: options})
+}
+
+func (a *asyncPrinter) enqueue(e asyncEntry) {
+	if !a.acquireSender() {
+		a.drop(false)
+		return
+	}
+	defer a.releaseSender()
+
+	a.mu.Lock()
+	a.queued++
+	a.mu.Unlock()
+
+	// Try without blocking
+	select {
+	case a.queue <- e:
+		return
+	default:
+	}
+
+	switch a.options.overflow {
+	case OverflowDropNewest:
+		a.drop(true)
+		return
+	case OverflowDropBelowLevel:
+		if e.level > a.options.dropLevel {
+			a.drop(true)
+			return
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.queue <- e:
+				return
+			case <-a.closing:
+				a.drop(true)
+				return
+			default:
+			}
+
+			// Discard the oldest entry. Writer may have taken it already, then retry to send
+			select {
+			case <-a.queue:
+				a.drop(true)
+			default:
+			}
+		}
+	}
+
+	// Block until queue has space or printer is closed
+	select {
+	case a.queue <- e:
+	case <-a.closing:
+		a.drop(true)
+	}
+}
+
+// acquireSender registers a sender. It returns false if printer is closed
+func (a *asyncPrinter) acquireSender() bool {
+	a.sendMu.Lock()
+	defer a.sendMu.Unlock()
+
+	if a.closed {
+		return false
+	}
+	a.senders++
+	return true
+}
+
+// releaseSender unregisters a sender. The last sender closes queue if printer is closed
+func (a *asyncPrinter) releaseSender() {
+	a.sendMu.Lock()
+	defer a.sendMu.Unlock()
+
+	a.senders--
+	a.closeQueue()
+}
+
+// closeQueue closes queue if printer is closed and no sender is sending. Caller must hold sendMu
+func (a *asyncPrinter) closeQueue() {
+	if a.closed && a.senders == 0 && !a.queueClosed {
+		a.queueClosed = true
+		close(a.queue)
+	}
+}
+
+// drop counts dropped entry. If queued is true, entry is marked as done
+func (a *asyncPrinter) drop(queued bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.dropped++
+	if queued {
+		a.markDone()
+	}
+}
+
+// markDone increments done counter and notifies waiters. Caller must hold the lock
+func (a *asyncPrinter) markDone() {
+	a.done++
+	close(a.progress)
+	a.progress = make(chan struct{})
+}
+
+func (a *asyncPrinter) run() {
+	defer close(a.exited)
+	for e := range a.queue {
+		a.print(e)
+		a.mu.Lock()
+		a.markDone()
+		a.mu.Unlock()
+	}
+}
+
+// print prints entry and recover panic, so writer goroutine will not be stopped
+func (a *asyncPrinter) print(e asyncEntry) {
+	defer func() {
+		_ = recover()
+	}()
+	a.printer.Print(e.namespace, e.level, e.msg, e.options)
+}
+
+// Dropped returns number of entries that are dropped by overflow policy or after printer is closed
+func (a *asyncPrinter) Dropped() uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.dropped
+}
+
+// Flush waits until entries that are queued before Flush is called are printed, or ctx is done
+func (a *asyncPrinter) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	target := a.queued
+	a.mu.Unlock()
+
+	for {
+		a.mu.Lock()
+		if a.done >= target {
+			a.mu.Unlock()
+			return nil
+		}
+		progress := a.progress
+		a.mu.Unlock()
+
+		select {
+		case <-progress:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Sync flushes all queued entries and underlying printer
+func (a *asyncPrinter) Sync() error {
+	if err := a.Flush(context.Background()); err != nil {
+		return err
+	}
+	return syncOf(a.printer)
+}
+
+// Close stops accepting entries, waits until queued entries are printed, then closes underlying printer.
+// If ctx is done before queue is drained, underlying printer is not closed. Entries that are blocked by full queue
+// or printed after Close are dropped
+func (a *asyncPrinter) Close(ctx context.Context) error {
+	a.sendMu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.closing)
+		a.closeQueue()
+	}
+	a.sendMu.Unlock()
+
+	select {
+	case <-a.exited:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	err := syncOf(a.printer)
+	if closeErr := closeOf(ctx, a.printer); closeErr != nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,321 @@
+package nlogger_test
+
+import (
+	"context"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedPrinter records entries and blocks each Print until released
+type gatedPrinter struct {
+	started chan struct{}
+	release chan struct{}
+
+	mu       sync.Mutex
+	messages []string
+	times    []time.Time
+}
+
+func newGatedPrinter() *gatedPrinter {
+	return &gatedPrinter{
+		started: make(chan struct{}, 100),
+		release: make(chan struct{}),
+	}
+}
+
+func (p *gatedPrinter) Print(_ string, _ level.LogLevel, msg string, options *logOption.Options) {
+	p.started <- struct{}{}
+	<-p.release
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, msg)
+	t, _ := options.Values[logOption.TimestampKey].(time.Time)
+	p.times = append(p.times, t)
+}
+
+func (p *gatedPrinter) Messages() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.messages...)
+}
+
+// fillQueue prints m0 that is taken by writer goroutine, then prints n more entries
+func fillQueue(p *gatedPrinter, a nlogger.Printer, lv level.LogLevel, n int) {
+	a.Print("", lv, "m0", nil)
+	<-p.started
+	for i := 1; i <= n; i++ {
+		a.Print("", lv, "m"+string(rune('0'+i)), nil)
+	}
+}
+
+func TestAsyncPrinter_DropNewest(t *testing.T) {
+	p := newGatedPrinter()
+	a := nlogger.NewAsyncPrinter(p, nlogger.WithQueueSize(2), nlogger.WithOverflowPolicy(nlogger.OverflowDropNewest))
+
+	fillQueue(p, a, level.Info, 4)
+	close(p.release)
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"m0", "m1", "m2"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+	if n := a.Dropped(); n != 2 {
+		t.Errorf("unexpected dropped count = %d", n)
+	}
+}
+
+func TestAsyncPrinter_DropOldest(t *testing.T) {
+	p := newGatedPrinter()
+	a := nlogger.NewAsyncPrinter(p, nlogger.WithQueueSize(2), nlogger.WithOverflowPolicy(nlogger.OverflowDropOldest))
+
+	fillQueue(p, a, level.Info, 4)
+	close(p.release)
+	_ = a.Close(context.Background())
+
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"m0", "m3", "m4"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+	if n := a.Dropped(); n != 2 {
+		t.Errorf("unexpected dropped count = %d", n)
+	}
+}
+
+func TestAsyncPrinter_DropBelowLevel(t *testing.T) {
+	p := newGatedPrinter()
+	a := nlogger.NewAsyncPrinter(p, nlogger.WithQueueSize(1), nlogger.WithDropLevel(level.Warn))
+
+	fillQueue(p, a, level.Warn, 1)
+
+	// Queue is full, info entry is dropped
+	a.Print("", level.Info, "info", nil)
+	if n := a.Dropped(); n != 1 {
+		t.Errorf("unexpected dropped count = %d", n)
+	}
+
+	// Error entry blocks until queue has space
+	sent := make(chan struct{})
+	go func() {
+		a.Print("", level.Error, "error", nil)
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+		t.Fatalf("expected error entry is blocked")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(p.release)
+	<-sent
+	_ = a.Close(context.Background())
+
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"m0", "m1", "error"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+}
+
+func TestAsyncPrinter_Block(t *testing.T) {
+	p := newGatedPrinter()
+	a := nlogger.NewAsyncPrinter(p, nlogger.WithQueueSize(1))
+
+	fillQueue(p, a, level.Info, 1)
+
+	sent := make(chan struct{})
+	go func() {
+		a.Print("", level.Info, "blocked", nil)
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+		t.Fatalf("expected entry is blocked")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(p.release)
+	<-sent
+	_ = a.Close(context.Background())
+
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"m0", "m1", "blocked"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+	if n := a.Dropped(); n != 0 {
+		t.Errorf("unexpected dropped count = %d", n)
+	}
+}
+
+func TestAsyncPrinter_Flush(t *testing.T) {
+	p := newGatedPrinter()
+	a := nlogger.NewAsyncPrinter(p)
+	log := nlogger.NewStdLogger(a, logOption.Level(level.Info))
+
+	before := time.Now()
+	log.Info("first")
+	log.Info("second")
+	after := time.Now()
+
+	// Writer is blocked, flush is timed out
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := a.Flush(ctx); err != context.DeadlineExceeded {
+		t.Errorf("unexpected flush error = %v", err)
+	}
+
+	close(p.release)
+	if err := a.Flush(context.Background()); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"first", "second"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+
+	// Entries keep the time when they are logged
+	for _, ts := range p.times {
+		if ts.Before(before) || ts.After(after) {
+			t.Errorf("unexpected entry time = %s", ts)
+		}
+	}
+
+	_ = a.Close(context.Background())
+}
+
+func TestAsyncPrinter_Close(t *testing.T) {
+	p := newGatedPrinter()
+	close(p.release)
+	a := nlogger.NewAsyncPrinter(p)
+
+	for i := 0; i < 10; i++ {
+		a.Print("", level.Info, "entry", nil)
+	}
+	if err := a.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if n := len(p.Messages()); n != 10 {
+		t.Errorf("expected queued entries are drained, got %d", n)
+	}
+
+	// Entry after close is dropped
+	a.Print("", level.Info, "closed", nil)
+	if n := a.Dropped(); n != 1 {
+		t.Errorf("unexpected dropped count = %d", n)
+	}
+
+	// Close is idempotent
+	if err := a.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error = %s", err)
+	}
+}
+
+func TestAsyncPrinter_Concurrent(t *testing.T) {
+	p := newGatedPrinter()
+	close(p.release)
+	p.started = make(chan struct{}, 1000)
+	a := nlogger.NewAsyncPrinter(p, nlogger.WithQueueSize(8))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				a.Print("", level.Info, "entry", nil)
+			}
+		}()
+	}
+	wg.Wait()
+	_ = a.Close(context.Background())
+
+	if n := len(p.Messages()); n != 500 {
+		t.Errorf("unexpected printed count = %d", n)
+	}
+}
+
+func TestAsyncPrinter_CopyOptions(t *testing.T) {
+	p := newGatedPrinter()
+	close(p.release)
+	a := nlogger.NewAsyncPrinter(p)
+
+	meta := map[string]interface{}{"k": "v"}
+	options := logOption.Evaluate([]logOption.SetterFunc{logOption.Metadata(meta)})
+	a.Print("", level.Info, "entry", options)
+	_ = a.Close(context.Background())
+
+	// Caller options must not be changed
+	if _, ok := options.Values[logOption.TimestampKey]; ok {
+		t.Errorf("expected timestamp is not set to caller options")
+	}
+	if reflect.ValueOf(options.Metadata).Pointer() != reflect.ValueOf(meta).Pointer() {
+		t.Errorf("expected metadata of caller options is not replaced")
+	}
+
+	if len(p.times) != 1 || p.times[0].IsZero() {
+		t.Errorf("expected queued entry has timestamp")
+	}
+}
+
+func TestAsyncPrinter_MultiOutputs(t *testing.T) {
+	a1 := nlogger.NewAsyncPrinter(nlogger.NewJSONPrinter(io.Discard))
+	a2 := nlogger.NewAsyncPrinter(nlogger.NewJSONPrinter(io.Discard))
+	log := nlogger.NewStdLogger(nlogger.NewMultiPrinter(nlogger.Output(a1, level.Trace), nlogger.Output(a2, level.Trace)),
+		logOption.Level(level.Info))
+
+	for i := 0; i < 100; i++ {
+		log.Info("entry", logOption.AddMetadata("i", i))
+	}
+
+	_ = a1.Close(context.Background())
+	_ = a2.Close(context.Background())
+}
+
+func TestAsyncPrinter_CloseTimeout(t *testing.T) {
+	p := newGatedPrinter()
+	a := nlogger.NewAsyncPrinter(p, nlogger.WithQueueSize(1))
+
+	// Writer is stuck, queue is full and a sender is blocked
+	fillQueue(p, a, level.Info, 1)
+	sent := make(chan struct{})
+	go func() {
+		a.Print("", level.Info, "blocked", nil)
+		close(sent)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := a.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("unexpected close error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Close returns when ctx is done, elapsed = %s", elapsed)
+	}
+
+	// Blocked sender is released and its entry is dropped
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatalf("expected blocked sender is released on close")
+	}
+
+	close(p.release)
+	if err := a.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error = %s", err)
+	}
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"m0", "m1"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+	if n := a.Dropped(); n != 1 {
+		t.Errorf("unexpected dropped count = %d", n)
+	}
+}
@@ -0,0 +1,85 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"github.com/nbs-go/nlogger/v2"
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"strings"
+	"testing"
+)
+
+func newChildTestLogger(buf *bytes.Buffer, args ...logOption.SetterFunc) *nlogger.StdLogger {
+	args = append(args, logOption.Level(level.Info))
+	return nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimestampKey(""), nlogger.WithLevelKey("")),
+		args...)
+}
+
+func TestNewChild_Metadata(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	root := newChildTestLogger(buf, logOption.AddMetadata("service", "api"))
+
+	child := root.NewChild(logOption.AddMetadata("tenant", "t1"), logOption.AddMetadata("job", "sync"))
+	grandchild := child.NewChild(logOption.AddMetadata("tenant", "t2"))
+
+	root.Info("root")
+	child.Info("child")
+	grandchild.Info("grandchild")
+	child.Info("call", logOption.AddMetadata("job", "override"), logOption.AddMetadata("attempt", 1))
+	child.Info("again")
+
+	expected := []string{
+		`{"message":"root","service":"api"}`,
+		`{"job":"sync","message":"child","service":"api","tenant":"t1"}`,
+		`{"job":"sync","message":"grandchild","service":"api","tenant":"t2"}`,
+		`{"attempt":1,"job":"override","message":"call","service":"api","tenant":"t1"}`,
+		`{"job":"sync","message":"again","service":"api","tenant":"t1"}`,
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected output =\n%s", buf.String())
+	}
+}
+
+func TestWith_Fields(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	root := newChildTestLogger(buf)
+
+	child := root.With(logField.String("request", "r1"), logField.String("user", "u1"))
+	grandchild := child.(*nlogger.StdLogger).With(logField.String("user", "u2"), logField.Int("step", 1))
+
+	child.Info("child")
+	grandchild.Info("grandchild")
+	nlogger.Log(child, level.Info, "call", logField.String("request", "r2"))
+	root.Info("root")
+
+	expected := []string{
+		`{"message":"child","request":"r1","user":"u1"}`,
+		`{"message":"grandchild","request":"r1","user":"u2","step":1}`,
+		`{"message":"call","request":"r2","user":"u1"}`,
+		`{"message":"root"}`,
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected output =\n%s", buf.String())
+	}
+}
+
+func TestWith_Proxy(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	type withLogger interface {
+		With(fields ...logField.Field) nlogger.Logger
+	}
+
+	// Create child before registering implementation
+	child := nlogger.Get().(withLogger).With(logField.String("job", "j1"))
+
+	buf := bytes.NewBuffer(nil)
+	nlogger.Register(newChildTestLogger(buf))
+	child.Info("proxy")
+
+	if expected := `{"message":"proxy","job":"j1"}` + "\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
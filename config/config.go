@@ -0,0 +1,191 @@
+package logConfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported configuration formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Supported printer types
+const (
+	PrinterStd    = "std"
+	PrinterJSON   = "json"
+	PrinterLogfmt = "logfmt"
+)
+
+// Supported output targets, other values are treated as file path
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+// Config is logger configuration
+type Config struct {
+	// Level is level specification as parsed by level.TryParseSpec, e.g. "info,db=debug"
+	Level string
+	// Namespaces contains level of each namespace, it overrides namespace levels in Level
+	Namespaces map[string]string
+	// Namespace is namespace of root logger
+	Namespace string
+	// Printer is printer type, one of PrinterStd, PrinterJSON and PrinterLogfmt. Default is PrinterStd
+	Printer string
+	// Outputs contains output targets. Default is stdout
+	Outputs []string
+	// TimeFormat is timestamp format of printer
+	TimeFormat string
+	// Sampling limits entries with the same level and message. If nil, all entries are printed
+	Sampling *SamplingConfig
+}
+
+// SamplingConfig is configuration of sampling printer
+type SamplingConfig struct {
+	// Tick is sampling interval. Default is 1s
+	Tick time.Duration
+	// First is number of entries that are printed in each tick
+	First int
+	// Thereafter is interval of entries that are printed after the first entries
+	Thereafter int
+}
+
+// Load reads configuration file. Format is detected from file extension, ".yaml" and ".yml" are parsed as YAML
+// and others are parsed as JSON
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data, formatOf(path))
+}
+
+// formatOf returns configuration format from file extension
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// Parse parses configuration in JSON or YAML format. Only a subset of YAML is supported: block mappings,
+// block sequences, flow sequences of scalars, quoted scalars and comments
+func Parse(data []byte, format string) (*Config, error) {
+	var raw map[string]interface{}
+	var err error
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(data, &raw)
+	case FormatYAML:
+		raw, err = parseYAML(string(data))
+	default:
+		err = fmt.Errorf("unsupported format %q", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("logConfig: failed to parse config. Error = %w", err)
+	}
+
+	return decode(raw)
+}
+
+// decode converts parsed document to Config
+func decode(raw map[string]interface{}) (*Config, error) {
+	var c Config
+	var err error
+	for k, v := range raw {
+		switch k {
+		case "level":
+			c.Level = toString(v)
+		case "namespace":
+			c.Namespace = toString(v)
+		case "printer":
+			c.Printer = toString(v)
+		case "timeFormat":
+			c.TimeFormat = toString(v)
+		case "namespaces":
+			m, ok := v.(map[string]interface{})
+			if !ok && v != nil {
+				return nil, fmt.Errorf("logConfig: namespaces must be a mapping")
+			}
+			c.Namespaces = make(map[string]string, len(m))
+			for ns, lv := range m {
+				c.Namespaces[ns] = toString(lv)
+			}
+		case "outputs":
+			switch t := v.(type) {
+			case []interface{}:
+				for _, o := range t {
+					c.Outputs = append(c.Outputs, toString(o))
+				}
+			case nil:
+			default:
+				c.Outputs = []string{toString(t)}
+			}
+		case "sampling":
+			if c.Sampling, err = decodeSampling(v); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("logConfig: unknown config key %q", k)
+		}
+	}
+	return &c, nil
+}
+
+func decodeSampling(v interface{}) (*SamplingConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("logConfig: sampling must be a mapping")
+	}
+
+	s := SamplingConfig{Tick: time.Second}
+	var err error
+	for k, sv := range m {
+		switch k {
+		case "tick":
+			if s.Tick, err = time.ParseDuration(toString(sv)); err != nil {
+				return nil, fmt.Errorf("logConfig: invalid sampling tick. Error = %w", err)
+			}
+		case "first":
+			if s.First, err = strconv.Atoi(toString(sv)); err != nil {
+				return nil, fmt.Errorf("logConfig: invalid sampling first. Error = %w", err)
+			}
+		case "thereafter":
+			if s.Thereafter, err = strconv.Atoi(toString(sv)); err != nil {
+				return nil, fmt.Errorf("logConfig: invalid sampling thereafter. Error = %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("logConfig: unknown sampling key %q", k)
+		}
+	}
+	return &s, nil
+}
+
+// toString converts scalar value to string
+func toString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprint(t)
+	}
+}
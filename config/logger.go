@@ -0,0 +1,248 @@
+package logConfig
+
+import (
+	"fmt"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	logWriter "github.com/nbs-go/nlogger/v2/writer"
+	"io"
+	stdLog "log"
+	"os"
+	"sync"
+)
+
+// Logger is a StdLogger that is constructed from Config. Levels and outputs can be re-applied at runtime,
+// the changes affect all child loggers
+type Logger struct {
+	*nlogger.StdLogger
+	printer *swapPrinter
+
+	mu         sync.Mutex
+	namespaces map[string]bool
+	closers    []io.Closer
+}
+
+// New constructs a Logger from configuration
+func New(c *Config) (*Logger, error) {
+	lv, namespaces, err := parseLevels(c)
+	if err != nil {
+		return nil, err
+	}
+
+	p, closers, err := newPrinter(c)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &swapPrinter{printer: p}
+	l := Logger{
+		StdLogger: nlogger.NewStdLogger(sp, logOption.WithNamespace(c.Namespace)),
+		printer:   sp,
+		closers:   closers,
+	}
+	l.applyLevels(lv, namespaces)
+	return &l, nil
+}
+
+// Apply re-applies levels and outputs from configuration. Outputs are replaced atomically, in-flight entries are
+// written to the previous outputs before they are closed. Namespace of root logger is not changed.
+// If configuration is invalid, then error is returned and the current configuration is kept
+func (l *Logger) Apply(c *Config) error {
+	lv, namespaces, err := parseLevels(c)
+	if err != nil {
+		return err
+	}
+
+	p, closers, err := newPrinter(c)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Swap printer and close previous outputs
+	l.printer.swap(p)
+	closeAll(l.closers)
+	l.closers = closers
+
+	l.applyLevels(lv, namespaces)
+	return nil
+}
+
+// Close closes file outputs. Logger must not be used after closed
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Wait for in-flight entries and flush printer
+	l.printer.wait()
+	err := l.printer.Sync()
+	if closeErr := closeAll(l.closers); closeErr != nil {
+		err = closeErr
+	}
+	l.closers = nil
+	return err
+}
+
+// Reopen reopens file outputs, e.g. after files are moved by logrotate
+func (l *Logger) Reopen() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var firstErr error
+	for _, c := range l.closers {
+		r, ok := c.(logWriter.Reopener)
+		if !ok {
+			continue
+		}
+		if err := r.Reopen(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// parseLevels returns root level and namespace levels from configuration. It returns error if a level is invalid
+func parseLevels(c *Config) (level.LogLevel, map[string]level.LogLevel, error) {
+	lv, namespaces, err := level.TryParseSpec(c.Level)
+	if err != nil {
+		return 0, nil, fmt.Errorf("logConfig: %w", err)
+	}
+
+	for ns, nsLevel := range c.Namespaces {
+		v, ok := level.TryParse(nsLevel)
+		if !ok {
+			return 0, nil, fmt.Errorf("logConfig: invalid level %q of namespace %q", nsLevel, ns)
+		}
+		namespaces[ns] = v
+	}
+	return lv, namespaces, nil
+}
+
+// applyLevels set root level and namespace levels. Namespaces that are removed from configuration will inherit
+// its parent level
+func (l *Logger) applyLevels(lv level.LogLevel, namespaces map[string]level.LogLevel) {
+	for ns := range l.namespaces {
+		if _, ok := namespaces[ns]; !ok {
+			l.StdLogger.ResetNamespaceLevel(ns)
+		}
+	}
+
+	l.namespaces = make(map[string]bool, len(namespaces))
+	for ns, nsLevel := range namespaces {
+		l.StdLogger.SetNamespaceLevel(ns, nsLevel)
+		l.namespaces[ns] = true
+	}
+	l.StdLogger.SetLevel(lv)
+}
+
+// newPrinter creates printer and opens outputs from configuration
+func newPrinter(c *Config) (nlogger.Printer, []io.Closer, error) {
+	out, closers, err := openOutputs(c.Outputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var args []nlogger.PrinterOption
+	if c.TimeFormat != "" {
+		args = append(args, nlogger.WithTimeFormat(c.TimeFormat))
+	}
+
+	var p nlogger.Printer
+	switch c.Printer {
+	case "", PrinterStd:
+		p = nlogger.NewStdLogPrinter(out, stdLog.LstdFlags, args...)
+	case PrinterJSON:
+		p = nlogger.NewJSONPrinter(out, args...)
+	case PrinterLogfmt:
+		p = nlogger.NewLogfmtPrinter(out, args...)
+	default:
+		closeAll(closers)
+		return nil, nil, fmt.Errorf("logConfig: unknown printer %q", c.Printer)
+	}
+
+	if s := c.Sampling; s != nil {
+		p = nlogger.NewSamplingPrinter(p, s.Tick, s.First, s.Thereafter)
+	}
+
+	return p, closers, nil
+}
+
+// openOutputs opens output targets and combine them as a writer
+func openOutputs(outputs []string) (io.Writer, []io.Closer, error) {
+	if len(outputs) == 0 {
+		return os.Stdout, nil, nil
+	}
+
+	writers := make([]io.Writer, 0, len(outputs))
+	var closers []io.Closer
+	for _, o := range outputs {
+		switch o {
+		case OutputStdout:
+			writers = append(writers, os.Stdout)
+		case OutputStderr:
+			writers = append(writers, os.Stderr)
+		default:
+			f, err := logWriter.NewRotatingFile(o)
+			if err != nil {
+				closeAll(closers)
+				return nil, nil, fmt.Errorf("logConfig: failed to open output %q. Error = %w", o, err)
+			}
+			writers = append(writers, f)
+			closers = append(closers, f)
+		}
+	}
+
+	if len(writers) == 1 {
+		return writers[0], closers, nil
+	}
+	return io.MultiWriter(writers...), closers, nil
+}
+
+func closeAll(closers []io.Closer) error {
+	var firstErr error
+	for _, c := range closers {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// swapPrinter is a Printer that its underlying printer can be replaced at runtime. Print holds read lock, so
+// swap will wait until in-flight entries are printed
+type swapPrinter struct {
+	mu      sync.RWMutex
+	printer nlogger.Printer
+}
+
+func (s *swapPrinter) Print(namespace string, outLevel level.LogLevel, msg string, options *logOption.Options) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.printer.Print(namespace, outLevel, msg, options)
+}
+
+// Sync flushes underlying printer if supported
+func (s *swapPrinter) Sync() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if p, ok := s.printer.(nlogger.Syncer); ok {
+		return p.Sync()
+	}
+	return nil
+}
+
+func (s *swapPrinter) swap(p nlogger.Printer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.printer = p
+}
+
+// wait blocks until in-flight entries are printed
+func (s *swapPrinter) wait() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+}
@@ -0,0 +1,120 @@
+package logConfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Watcher polls configuration file and re-applies it to Logger when the file is changed
+type Watcher struct {
+	logger   *Logger
+	path     string
+	interval time.Duration
+	onError  func(error)
+
+	content  []byte
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// Watch loads configuration file, constructs Logger and starts polling the file for changes in every interval.
+// If file is failed to be reloaded, then onError is called, the current configuration is kept and file is reloaded
+// again in the next interval.
+// Interval must be positive
+func Watch(path string, interval time.Duration, onError func(error)) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("logConfig: watch interval must be positive, got %s", interval)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := Parse(content, formatOf(path))
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := New(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if onError == nil {
+		onError = func(err error) {
+			l.Errorf("logConfig: failed to reload config. Error = %s", err)
+		}
+	}
+
+	w := Watcher{
+		logger:   l,
+		path:     path,
+		interval: interval,
+		onError:  onError,
+		content:  content,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return &w, nil
+}
+
+// Logger returns Logger that is constructed from configuration file
+func (w *Watcher) Logger() *Logger {
+	return w.logger
+}
+
+// Stop stops polling configuration file. Logger is not closed
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+}
+
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+// reload re-applies configuration if file content is changed
+func (w *Watcher) reload() {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		w.onError(err)
+		return
+	}
+
+	if bytes.Equal(content, w.content) {
+		return
+	}
+
+	c, err := Parse(content, formatOf(w.path))
+	if err != nil {
+		w.onError(err)
+		return
+	}
+
+	// Keep the previous content if failed, so configuration is applied again in the next interval
+	if err = w.logger.Apply(c); err != nil {
+		w.onError(err)
+		return
+	}
+	w.content = content
+}
@@ -0,0 +1,199 @@
+package logConfig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a non-empty line of YAML document without comment
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML parses a subset of YAML into a mapping. All scalars are parsed as string
+func parseYAML(doc string) (map[string]interface{}, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(doc, "\n") {
+		text := strings.TrimRight(stripYAMLComment(raw), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tab indentation is not allowed", i+1)
+		}
+
+		lines = append(lines, yamlLine{number: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+
+	if len(lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	p := yamlParser{lines: lines}
+	v, err := p.parseBlock(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", lines[p.pos].number)
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("document must be a mapping")
+	}
+	return m, nil
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseBlock parses a mapping or sequence at indentation
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isYAMLSequenceItem(p.lines[p.pos].text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseMapping(indent int) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+
+		if line.indent > indent || isYAMLSequenceItem(line.text) {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+
+		i := strings.Index(line.text, ":")
+		if i <= 0 || (i+1 < len(line.text) && line.text[i+1] != ' ') {
+			return nil, fmt.Errorf("line %d: expected mapping key", line.number)
+		}
+
+		key, err := parseYAMLScalar(strings.TrimSpace(line.text[:i]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line.number, err)
+		}
+		rest := strings.TrimSpace(line.text[i+1:])
+		p.pos++
+
+		// If value is empty, then parse nested block
+		if rest == "" {
+			if p.pos < len(p.lines) && (p.lines[p.pos].indent > indent ||
+				(p.lines[p.pos].indent == indent && isYAMLSequenceItem(p.lines[p.pos].text))) {
+				m[key], err = p.parseBlock(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				m[key] = nil
+			}
+			continue
+		}
+
+		if m[key], err = parseYAMLValue(rest); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line.number, err)
+		}
+	}
+	return m, nil
+}
+
+func (p *yamlParser) parseSequence(indent int) ([]interface{}, error) {
+	var list []interface{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent || (line.indent == indent && !isYAMLSequenceItem(line.text)) {
+			break
+		}
+
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+
+		v, err := parseYAMLValue(strings.TrimSpace(strings.TrimPrefix(line.text, "-")))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line.number, err)
+		}
+		list = append(list, v)
+		p.pos++
+	}
+	return list, nil
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseYAMLValue parses flow sequence or scalar
+func parseYAMLValue(s string) (interface{}, error) {
+	if !strings.HasPrefix(s, "[") {
+		return parseYAMLScalar(s)
+	}
+
+	if !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("unterminated flow sequence")
+	}
+
+	list := make([]interface{}, 0)
+	inner := strings.TrimSpace(s[1 : len(s)-1])
+	if inner == "" {
+		return list, nil
+	}
+
+	for _, item := range strings.Split(inner, ",") {
+		v, err := parseYAMLScalar(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// parseYAMLScalar parses plain, single-quoted or double-quoted scalar
+func parseYAMLScalar(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted scalar %s", s)
+		}
+		return v, nil
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("invalid single-quoted scalar %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	default:
+		return s, nil
+	}
+}
+
+// stripYAMLComment removes comment that is not inside quoted scalar
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,238 @@
+package nlogger_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/nbs-go/nlogger/v2"
+	logConfig "github.com/nbs-go/nlogger/v2/config"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_ParseYAML(t *testing.T) {
+	doc := `
+# Logger configuration
+level: info,db=debug
+namespace: "app" # root namespace
+printer: json
+namespaces:
+  http.client: trace
+  'cache': warn
+outputs:
+  - stdout
+  - /var/log/app.log
+timeFormat: "2006-01-02T15:04:05Z07:00"
+sampling:
+  tick: 2s
+  first: 100
+  thereafter: 10
+`
+	c, err := logConfig.Parse([]byte(doc), logConfig.FormatYAML)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	expected := &logConfig.Config{
+		Level:      "info,db=debug",
+		Namespace:  "app",
+		Printer:    logConfig.PrinterJSON,
+		Namespaces: map[string]string{"http.client": "trace", "cache": "warn"},
+		Outputs:    []string{logConfig.OutputStdout, "/var/log/app.log"},
+		TimeFormat: time.RFC3339,
+		Sampling:   &logConfig.SamplingConfig{Tick: 2 * time.Second, First: 100, Thereafter: 10},
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("unexpected config.\nExpected = %+v\nActual   = %+v", expected, c)
+	}
+
+	// JSON config must produce the same result
+	jsonDoc := `{"level": "info,db=debug", "namespace": "app", "printer": "json",
+		"namespaces": {"http.client": "trace", "cache": "warn"}, "outputs": ["stdout", "/var/log/app.log"],
+		"timeFormat": "2006-01-02T15:04:05Z07:00", "sampling": {"tick": "2s", "first": 100, "thereafter": 10}}`
+	c, err = logConfig.Parse([]byte(jsonDoc), logConfig.FormatJSON)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("unexpected config.\nExpected = %+v\nActual   = %+v", expected, c)
+	}
+}
+
+func TestConfig_ParseInvalid(t *testing.T) {
+	docs := []string{
+		"level: info\n  namespace: app",
+		"unknown: value",
+		"sampling:\n  first: many",
+		"outputs: [stdout",
+	}
+
+	for _, doc := range docs {
+		if _, err := logConfig.Parse([]byte(doc), logConfig.FormatYAML); err == nil {
+			t.Errorf("expected error when parsing %q", doc)
+		}
+	}
+}
+
+func TestConfig_Watch(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "log.yaml")
+	logPath := filepath.Join(dir, "app.log")
+
+	writeConfig := func(content string) {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error = %s", err)
+		}
+	}
+
+	writeConfig("level: info\nprinter: logfmt\noutputs: [" + logPath + "]\n")
+	w, err := logConfig.Watch(configPath, 10*time.Millisecond, func(err error) {
+		t.Errorf("unexpected reload error = %s", err)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer w.Stop()
+
+	l := w.Logger()
+	defer func() {
+		_ = l.Close()
+	}()
+	child := l.NewChild(logOption.WithNamespace("db"))
+	child.Debug("this should not appear")
+	child.Info("first")
+
+	// Change level and printer
+	writeConfig("level: warn,db=debug\nprinter: json\noutputs: [" + logPath + "]\n")
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && l.GetLevel() != level.Warn {
+		time.Sleep(5 * time.Millisecond)
+	}
+	child.Debug("second")
+	l.Info("this should not appear")
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	var lines []string
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("unexpected output = %s", content)
+	}
+
+	if !bytes.Contains([]byte(lines[0]), []byte("msg=first")) {
+		t.Errorf("unexpected logfmt entry = %s", lines[0])
+	}
+
+	var entry map[string]interface{}
+	if err = json.Unmarshal([]byte(lines[1]), &entry); err != nil || entry["message"] != "second" {
+		t.Errorf("unexpected json entry = %s", lines[1])
+	}
+}
+
+func TestConfig_WatchInterval(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "log.yaml")
+	if err := os.WriteFile(configPath, []byte("level: info\n"), 0644); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := logConfig.Watch(configPath, interval, nil); err == nil {
+			t.Errorf("expected error when watch interval is %s", interval)
+		}
+	}
+}
+
+func TestConfig_InvalidLevel(t *testing.T) {
+	if _, err := logConfig.New(&logConfig.Config{Level: "debgu"}); err == nil {
+		t.Errorf("expected error when level is invalid")
+	}
+
+	l, err := logConfig.New(&logConfig.Config{Level: "info,db=debug"})
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer func() {
+		_ = l.Close()
+	}()
+
+	// Invalid levels must not change the current configuration
+	configs := []*logConfig.Config{
+		{Level: "warn,db=debgu"},
+		{Level: "warn", Namespaces: map[string]string{"db": "verbose"}},
+	}
+	for _, c := range configs {
+		if err = l.Apply(c); err == nil {
+			t.Errorf("expected error when applying %+v", c)
+		}
+	}
+
+	if lv := l.GetLevel(); lv != level.Info {
+		t.Errorf("unexpected level = %d", lv)
+	}
+	if !nlogger.Enabled(l.NewChild(logOption.WithNamespace("db")), level.Debug) {
+		t.Errorf("expected namespace level is kept")
+	}
+}
+
+func TestConfig_WatchRetry(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "log.yaml")
+	blocker := filepath.Join(dir, "blocker")
+
+	writeConfig := func(content string) {
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("unexpected error = %s", err)
+		}
+	}
+
+	writeConfig("level: info\noutputs: [" + filepath.Join(dir, "app.log") + "]\n")
+	errCh := make(chan error, 100)
+	w, err := logConfig.Watch(configPath, 10*time.Millisecond, func(err error) {
+		errCh <- err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer w.Stop()
+
+	l := w.Logger()
+	defer func() {
+		_ = l.Close()
+	}()
+
+	// Output cannot be opened, since its directory is a file
+	if err = os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	writeConfig("level: warn\noutputs: [" + filepath.Join(blocker, "app.log") + "]\n")
+	select {
+	case <-errCh:
+	case <-time.After(time.Second):
+		t.Fatalf("expected reload error")
+	}
+	if lv := l.GetLevel(); lv != level.Info {
+		t.Errorf("unexpected level = %d", lv)
+	}
+
+	// Configuration is applied again without changing file
+	_ = os.Remove(blocker)
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) && l.GetLevel() != level.Warn {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if lv := l.GetLevel(); lv != level.Warn {
+		t.Errorf("unexpected level = %d", lv)
+	}
+}
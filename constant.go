@@ -6,4 +6,5 @@ const pkgNamespace = "nlogger"
 const (
 	EnvLogLevel     = "LOG_LEVEL"
 	EnvLogNamespace = "LOG_NAMESPACE"
+	EnvLogDriver    = "LOG_DRIVER"
 )
@@ -0,0 +1,173 @@
+package nlogger
+
+import (
+	"fmt"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	stdLog "log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Built-in driver names
+const (
+	DriverStd    = "std"
+	DriverJSON   = "json"
+	DriverLogfmt = "logfmt"
+)
+
+// DriverConfig is configuration that is passed to DriverFactory to construct a Logger
+type DriverConfig struct {
+	// Level is level specification as parsed by level.ParseSpec, e.g. "info,db=debug"
+	Level string
+	// Namespace is namespace of root logger
+	Namespace string
+	// Output is writer of log entries. If nil, driver should write to stdout
+	Output io.Writer
+	// Options contains driver specific options
+	Options map[string]string
+}
+
+// DriverFactory constructs a Logger from configuration
+type DriverFactory = func(config DriverConfig) (Logger, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]DriverFactory)
+)
+
+func init() {
+	RegisterDriver(DriverStd, newStdDriver)
+	RegisterDriver(DriverJSON, newJSONDriver)
+	RegisterDriver(DriverLogfmt, newLogfmtDriver)
+}
+
+// RegisterDriver makes a logger driver available by the provided name. It is intended to be called from init()
+// function of logger implementation package. If RegisterDriver is called twice with the same name or if factory
+// is nil, it panics
+func RegisterDriver(name string, factory DriverFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if factory == nil {
+		panic(fmt.Errorf("%s: driver factory is nil", pkgNamespace))
+	}
+
+	if _, dup := drivers[name]; dup {
+		panic(fmt.Errorf("%s: RegisterDriver called twice for driver %s", pkgNamespace, name))
+	}
+	drivers[name] = factory
+}
+
+// Drivers returns a sorted list of the names of the registered drivers
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	list := make([]string, 0, len(drivers))
+	for name := range drivers {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Open constructs a Logger with the driver that is registered with the provided name. The returned Logger
+// is not registered, call Register to use it as the default logger
+func Open(name string, config DriverConfig) (Logger, error) {
+	driversMu.RLock()
+	factory, ok := drivers[name]
+	driversMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown driver %q (forgotten import?)", pkgNamespace, name)
+	}
+
+	return factory(config)
+}
+
+// stdLoggerArgs returns StdLogger options from driver configuration
+func (c DriverConfig) stdLoggerArgs() []logOption.SetterFunc {
+	lv, namespaceLevels := level.ParseSpec(c.Level)
+	return []logOption.SetterFunc{
+		logOption.Level(lv),
+		logOption.NamespaceLevels(namespaceLevels),
+		logOption.WithNamespace(c.Namespace),
+	}
+}
+
+// printerArgs returns built-in Printer options from driver configuration
+func (c DriverConfig) printerArgs() []PrinterOption {
+	var args []PrinterOption
+	if layout, ok := c.Options["timeFormat"]; ok {
+		args = append(args, WithTimeFormat(layout))
+	}
+	if singleLine, _ := strconv.ParseBool(c.Options["singleLine"]); singleLine {
+		args = append(args, WithSingleLine())
+	}
+	return args
+}
+
+func newStdDriver(c DriverConfig) (Logger, error) {
+	flag := stdLog.LstdFlags
+	if v, ok := c.Options["flag"]; ok {
+		var err error
+		if flag, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%s: invalid flag option %q", pkgNamespace, v)
+		}
+	}
+
+	p := NewStdLogPrinter(c.Output, flag, c.printerArgs()...)
+	return NewStdLogger(p, c.stdLoggerArgs()...), nil
+}
+
+func newJSONDriver(c DriverConfig) (Logger, error) {
+	p := NewJSONPrinter(c.Output, c.printerArgs()...)
+	return NewStdLogger(p, c.stdLoggerArgs()...), nil
+}
+
+func newLogfmtDriver(c DriverConfig) (Logger, error) {
+	p := NewLogfmtPrinter(c.Output, c.printerArgs()...)
+	return NewStdLogger(p, c.stdLoggerArgs()...), nil
+}
+
+// fallbackConfig returns driver configuration from env
+func fallbackConfig(out io.Writer) DriverConfig {
+	logLevel, _ := os.LookupEnv(EnvLogLevel)
+	namespace, _ := os.LookupEnv(EnvLogNamespace)
+	return DriverConfig{
+		Level:     logLevel,
+		Namespace: namespace,
+		Output:    out,
+	}
+}
+
+// newFallbackLogger creates Logger that is configured from env. If driver is set in env, then logger is
+// constructed by the driver, otherwise StdLogger is used. It must not be called while holding registry lock,
+// since driver factory may call this package
+func newFallbackLogger(out io.Writer) Logger {
+	config := fallbackConfig(out)
+
+	// Get driver from env
+	name, _ := os.LookupEnv(EnvLogDriver)
+	if name == "" {
+		name = DriverStd
+	}
+
+	l, err := Open(name, config)
+	if err == nil {
+		// Resolve proxy, so fallback logger will not resolve itself
+		if p, ok := l.(*proxyLogger); ok {
+			l = p.resolve()
+		}
+		return l
+	}
+
+	// If failed, fallback to StdLogger
+	l, _ = newStdDriver(config)
+	l.Warnf("%s: failed to open logger driver, fallback to StdLogger. Error = %s", pkgNamespace, err)
+	return l
+}
@@ -0,0 +1,192 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/nbs-go/nlogger/v2"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testDriverLogger = nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0))
+
+var reentrantDriverLogger = nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0))
+
+// slowDriverOutput is output of logger opened by slow driver
+var slowDriverOutput = &lockedBuffer{}
+
+// lockedBuffer is a buffer that is safe for concurrent writes
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func init() {
+	nlogger.RegisterDriver("test", func(config nlogger.DriverConfig) (nlogger.Logger, error) {
+		return testDriverLogger, nil
+	})
+
+	// Driver that logs and registers logger while it is constructed
+	nlogger.RegisterDriver("reentrant", func(config nlogger.DriverConfig) (nlogger.Logger, error) {
+		nlogger.Get().Info("opening reentrant driver")
+		nlogger.NewChild(logOption.WithNamespace("driver")).Info("child")
+		nlogger.Register(reentrantDriverLogger)
+		return nlogger.Get(), nil
+	})
+
+	// Driver that takes time to open JSON logger
+	nlogger.RegisterDriver("slow", func(config nlogger.DriverConfig) (nlogger.Logger, error) {
+		time.Sleep(50 * time.Millisecond)
+		config.Output = slowDriverOutput
+		return nlogger.Open(nlogger.DriverJSON, config)
+	})
+}
+
+func TestDrivers(t *testing.T) {
+	expected := []string{nlogger.DriverJSON, nlogger.DriverLogfmt, "reentrant", "slow", nlogger.DriverStd, "test"}
+	if drivers := nlogger.Drivers(); !reflect.DeepEqual(drivers, expected) {
+		t.Errorf("unexpected drivers = %v", drivers)
+	}
+}
+
+func TestRegisterDriver_Duplicate(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+	}()
+
+	nlogger.RegisterDriver(nlogger.DriverStd, func(config nlogger.DriverConfig) (nlogger.Logger, error) {
+		return nil, nil
+	})
+}
+
+func TestOpen(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l, err := nlogger.Open(nlogger.DriverJSON, nlogger.DriverConfig{
+		Level:     "info,db=debug",
+		Namespace: "app",
+		Output:    buf,
+		Options:   map[string]string{"timeFormat": nlogger.TimeFormatUnix},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	l.Debug("this should not appear")
+	l.NewChild().Info("hello")
+
+	var entry map[string]interface{}
+	if err = json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	if entry["message"] != "hello" || entry["namespace"] != "app" {
+		t.Errorf("unexpected entry = %v", entry)
+	}
+
+	if _, ok := entry["timestamp"].(float64); !ok {
+		t.Errorf("unexpected timestamp = %v", entry["timestamp"])
+	}
+
+	// Open unknown driver
+	if _, err = nlogger.Open("unknown", nlogger.DriverConfig{}); err == nil {
+		t.Errorf("expected error when opening unknown driver")
+	}
+}
+
+func TestGet_LogDriver(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	_ = os.Setenv(nlogger.EnvLogDriver, "test")
+	defer func() {
+		_ = os.Unsetenv(nlogger.EnvLogDriver)
+	}()
+
+	if l := nlogger.Unwrap(nlogger.Get()); l != testDriverLogger {
+		t.Errorf("logger is not opened from driver in env")
+	}
+}
+
+func TestGet_ReentrantDriver(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	_ = os.Setenv(nlogger.EnvLogDriver, "reentrant")
+	defer func() {
+		_ = os.Unsetenv(nlogger.EnvLogDriver)
+	}()
+
+	done := make(chan nlogger.Logger)
+	go func() {
+		done <- nlogger.Unwrap(nlogger.Get())
+	}()
+
+	select {
+	case l := <-done:
+		// Logger registered by factory is kept
+		if l != reentrantDriverLogger {
+			t.Errorf("unexpected registered logger")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("deadlock when driver factory calls nlogger")
+	}
+}
+
+func TestGet_SlowDriverConcurrently(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+	slowDriverOutput.Reset()
+
+	_ = os.Setenv(nlogger.EnvLogDriver, "slow")
+	defer func() {
+		_ = os.Unsetenv(nlogger.EnvLogDriver)
+	}()
+
+	// Entries written while fallback logger is constructed must be printed by the driver logger
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			nlogger.Get().Errorf("concurrent %d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(slowDriverOutput.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("unexpected output = %q", slowDriverOutput.String())
+	}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Errorf("unexpected entry = %s", line)
+		}
+	}
+}
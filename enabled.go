@@ -0,0 +1,51 @@
+package nlogger
+
+import (
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+)
+
+// LevelEnabler is implemented by Logger that can report whether entries in a level will be printed
+type LevelEnabler interface {
+	Enabled(lv level.LogLevel) bool
+}
+
+// Enabled returns true if entries in level lv will be printed by logger
+func (l *StdLogger) Enabled(lv level.LogLevel) bool {
+	return l.level.Enabled(lv)
+}
+
+// Enabled returns true if entries in level lv will be printed by l. If l does not implement LevelEnabler,
+// it returns true
+func Enabled(l Logger, lv level.LogLevel) bool {
+	if e, ok := l.(LevelEnabler); ok {
+		return e.Enabled(lv)
+	}
+	return true
+}
+
+// resolveLazy computes lazy metadata values, lazy fields and lazy format arguments of entry, so lazy functions
+// are called once before entry is passed to printer
+func resolveLazy(options *logOption.Options) {
+	options.Metadata = logOption.ResolveMetadata(options.Metadata)
+	options.FmtArgs = logOption.ResolveArgs(options.FmtArgs)
+
+	var fields []logField.Field
+	for i, f := range options.Fields {
+		if f.Type != logField.LazyType {
+			continue
+		}
+
+		// Copy fields, so the original slice is not changed
+		if fields == nil {
+			fields = make([]logField.Field, len(options.Fields))
+			copy(fields, options.Fields)
+		}
+		fields[i] = f.Resolve()
+	}
+
+	if fields != nil {
+		options.Fields = fields
+	}
+}
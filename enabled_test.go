@@ -0,0 +1,168 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/nbs-go/nlogger/v2"
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"testing"
+)
+
+// plainLogger is a Logger that does not implement LevelEnabler
+type plainLogger struct {
+	nlogger.Logger
+}
+
+func TestEnabled(t *testing.T) {
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(&bytes.Buffer{}, 0), logOption.Level(level.Info))
+
+	if !log.Enabled(level.Info) || !log.Enabled(level.Error) || log.Enabled(level.Debug) {
+		t.Errorf("unexpected enabled levels")
+	}
+
+	child := log.NewChild(logOption.WithNamespace("db"))
+	log.SetNamespaceLevel("db", level.Debug)
+	if !nlogger.Enabled(child, level.Debug) {
+		t.Errorf("expected namespace level is enabled")
+	}
+
+	if !nlogger.Enabled(plainLogger{log}, level.Trace) {
+		t.Errorf("expected logger without LevelEnabler is always enabled")
+	}
+}
+
+func TestEnabled_Proxy(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	proxy := nlogger.NewChild(logOption.WithNamespace("proxy"))
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(&bytes.Buffer{}, 0), logOption.Level(level.Warn)))
+
+	if nlogger.Enabled(proxy, level.Info) || !nlogger.Enabled(proxy, level.Warn) {
+		t.Errorf("unexpected enabled levels of proxy")
+	}
+
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(&bytes.Buffer{}, 0), logOption.Level(level.Trace)))
+	if !nlogger.Enabled(nlogger.Get(), level.Trace) {
+		t.Errorf("expected proxy resolves the registered logger")
+	}
+}
+
+func TestLazy_Metadata(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimestampKey(""), nlogger.WithLevelKey("")),
+		logOption.Level(level.Info))
+
+	calls := 0
+	payload := func() interface{} {
+		calls++
+		return map[string]int{"size": 3}
+	}
+
+	log.Debug("disabled", logOption.AddLazyMetadata("payload", payload))
+	if calls != 0 {
+		t.Fatalf("expected lazy value is not computed if level is disabled")
+	}
+
+	meta := map[string]interface{}{"payload": logOption.Lazy(payload)}
+	log.Info("enabled", logOption.Metadata(meta))
+	if calls != 1 {
+		t.Errorf("unexpected computed count = %d", calls)
+	}
+	if _, ok := meta["payload"].(logOption.LazyValue); !ok {
+		t.Errorf("expected metadata of caller is not changed")
+	}
+
+	if expected := `{"message":"enabled","payload":{"size":3}}` + "\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
+
+func TestLazy_Field(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimestampKey(""), nlogger.WithLevelKey("")),
+		logOption.Level(level.Info))
+
+	calls := 0
+	counter := logField.Lazy("calls", func() interface{} {
+		calls++
+		return calls
+	})
+
+	log.Log(level.Debug, "disabled", counter)
+	if calls != 0 {
+		t.Fatalf("expected lazy field is not computed if level is disabled")
+	}
+
+	// Persistent lazy field is computed on every entry
+	child := log.With(counter)
+	child.Info("first")
+	child.Info("second")
+
+	expected := `{"message":"first","calls":1}` + "\n" + `{"message":"second","calls":2}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
+
+func TestLazy_Format(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Info))
+
+	calls := 0
+	pi := logOption.Lazy(func() interface{} {
+		calls++
+		return 3.14159
+	})
+
+	log.Debugf("disabled %v", pi)
+	log.Infof("pi = %5.2f|%-6v|", pi, pi)
+
+	if calls != 2 {
+		t.Errorf("unexpected computed count = %d", calls)
+	}
+	if expected := " [INFO] pi =  3.14|3.14159|\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+
+	if s := fmt.Sprintf("%+d", logOption.Lazy(func() interface{} { return 5 })); s != "+5" {
+		t.Errorf("unexpected formatted flag = %s", s)
+	}
+}
+
+func TestLazy_FormatMultiOutputs(t *testing.T) {
+	buf1 := bytes.NewBuffer(nil)
+	buf2 := bytes.NewBuffer(nil)
+	p := nlogger.NewMultiPrinter(nlogger.Output(nlogger.NewStdLogPrinter(buf1, 0), level.Trace),
+		nlogger.Output(nlogger.NewStdLogPrinter(buf2, 0), level.Trace))
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Info))
+
+	calls := 0
+	counter := logOption.Lazy(func() interface{} {
+		calls++
+		return calls
+	})
+
+	log.Debugf("disabled %v", counter)
+	log.Infof("calls = %d", counter)
+
+	// Lazy argument is computed once for all outputs
+	if calls != 1 {
+		t.Errorf("unexpected computed count = %d", calls)
+	}
+
+	// Lazy argument is computed once for all outputs and fatal hook
+	var hookMsg string
+	log.NewChild(logOption.FatalHook(func(msg string) {
+		hookMsg = msg
+	})).Fatalf("calls = %d", counter)
+	if calls != 2 || hookMsg != "calls = 2" {
+		t.Errorf("unexpected computed count = %d, hook message = %s", calls, hookMsg)
+	}
+
+	if expected := " [INFO] calls = 1\n[FATAL] calls = 2\n"; buf1.String() != expected || buf2.String() != expected {
+		t.Errorf("unexpected output = %q, %q", buf1.String(), buf2.String())
+	}
+}
@@ -0,0 +1,95 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"reflect"
+	"testing"
+)
+
+// fieldError is an error that carries structured fields
+type fieldError struct {
+	fields map[string]interface{}
+}
+
+func (e *fieldError) Error() string {
+	return "field error"
+}
+
+func (e *fieldError) LogFields() map[string]interface{} {
+	return e.fields
+}
+
+// multiError is an error that wraps multiple errors like errors.Join
+type multiError []error
+
+func (e multiError) Error() string {
+	return "multi error"
+}
+
+func (e multiError) Unwrap() []error {
+	return e
+}
+
+func TestErrorChain(t *testing.T) {
+	err := fmt.Errorf("outer: %w", multiError{errors.New("first"), fmt.Errorf("second: %w", errors.New("third"))})
+
+	expected := []logOption.ErrorCause{
+		{Type: "*fmt.wrapError", Message: "outer: multi error"},
+		{Type: "nlogger_test.multiError", Message: "multi error"},
+		{Type: "*errors.errorString", Message: "first"},
+		{Type: "*fmt.wrapError", Message: "second: third"},
+		{Type: "*errors.errorString", Message: "third"},
+	}
+
+	if chain := logOption.ErrorChain(err); !reflect.DeepEqual(chain, expected) {
+		t.Errorf("unexpected error chain = %+v", chain)
+	}
+}
+
+func TestStdLogPrinter_ErrorChain(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Error))
+	log.Error("message", logOption.Error(fmt.Errorf("caused by => %w", errors.New("source of error"))))
+
+	expected := "[ERROR] message\n  > Error: caused by => source of error\n" +
+		"  >   Caused by (*errors.errorString): source of error\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
+
+func TestJSONPrinter_ErrorFields(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithErrorFields()), logOption.Level(level.Error))
+
+	inner := &fieldError{fields: map[string]interface{}{"userId": "inner", "code": "E01"}}
+	outer := &fieldError{fields: map[string]interface{}{"userId": "outer"}}
+	err := fmt.Errorf("wrapped: %w", multiError{outer, inner})
+	log.Error("message", logOption.Error(err), logOption.AddMetadata("code", "E02"))
+
+	var entry struct {
+		UserId     string                 `json:"userId"`
+		Code       string                 `json:"code"`
+		ErrorChain []logOption.ErrorCause `json:"errorChain"`
+	}
+	if err = json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	if entry.UserId != "outer" || entry.Code != "E02" || len(entry.ErrorChain) != 4 {
+		t.Errorf("unexpected entry = %+v", entry)
+	}
+}
+
+func TestGetError_InvalidValue(t *testing.T) {
+	o := logOption.Evaluate([]logOption.SetterFunc{logOption.Error(nil)})
+	if err := logOption.GetError(o, logOption.ErrorKey); err != nil {
+		t.Errorf("unexpected error = %s", err)
+	}
+}
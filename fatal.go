@@ -0,0 +1,89 @@
+package nlogger
+
+import (
+	"fmt"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"os"
+	"sync"
+)
+
+// exitFunc is function to terminate process, it can be replaced for testing
+var exitFunc = os.Exit
+var exitMutex sync.RWMutex
+
+// SetExitFunc replace function that is called by FatalExit action to terminate process, e.g. to intercept
+// exit in tests. It returns a function to restore the previous exit function
+func SetExitFunc(fn func(code int)) (restore func()) {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+
+	prev := exitFunc
+	exitFunc = fn
+	return func() {
+		exitMutex.Lock()
+		defer exitMutex.Unlock()
+		exitFunc = prev
+	}
+}
+
+func exit(code int) {
+	// Flush startup buffer, so buffered entries will not be lost
+	FlushStartupBuffer()
+
+	exitMutex.RLock()
+	fn := exitFunc
+	exitMutex.RUnlock()
+	fn(code)
+}
+
+// fatalHandler holds fatal behaviour of a logger
+type fatalHandler struct {
+	action   logOption.FatalAction
+	exitCode int
+	hook     func(msg string)
+}
+
+func newFatalHandler(o *logOption.Options) fatalHandler {
+	h := fatalHandler{exitCode: 1}
+	h.override(o)
+	return h
+}
+
+// override set fatal behaviour that is set in options
+func (h *fatalHandler) override(o *logOption.Options) {
+	if action, ok := o.Values[logOption.FatalActionKey].(logOption.FatalAction); ok {
+		h.action = action
+	}
+	if code, ok := logOption.GetInt(o, logOption.FatalExitCodeKey); ok {
+		h.exitCode = code
+	}
+	if hook, ok := o.Values[logOption.FatalHookKey].(func(msg string)); ok {
+		h.hook = hook
+	}
+}
+
+// active returns true if hook or fatal action is set
+func (h *fatalHandler) active() bool {
+	return h.action != logOption.FatalNone || h.hook != nil
+}
+
+// handle flush printer, call hook and execute fatal action
+func (h *fatalHandler) handle(p Printer, msg string) {
+	if !h.active() {
+		return
+	}
+
+	// Flush printer if supported, so entries will not be lost when process is terminated
+	_ = syncOf(p)
+
+	if h.hook != nil {
+		h.hook(msg)
+	}
+
+	switch h.action {
+	case logOption.FatalExit:
+		exit(h.exitCode)
+	case logOption.FatalPanic:
+		panic(fmt.Errorf("%s: %s", pkgNamespace, msg))
+	}
+}
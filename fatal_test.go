@@ -0,0 +1,89 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"testing"
+)
+
+func TestFatal_Exit(t *testing.T) {
+	exitCode := -1
+	restore := nlogger.SetExitFunc(func(code int) {
+		exitCode = code
+	})
+	defer restore()
+
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.OnFatal(logOption.FatalExit))
+	child := log.NewChild(logOption.FatalExitCode(2))
+
+	child.Fatalf("exit with code %d", 2)
+	if exitCode != 2 {
+		t.Errorf("unexpected exit code = %d", exitCode)
+	}
+
+	if expected := "[FATAL] exit with code 2\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+
+	// Parent exit code must not be overridden
+	log.Fatal("exit with default code")
+	if exitCode != 1 {
+		t.Errorf("unexpected exit code = %d", exitCode)
+	}
+}
+
+func TestFatal_Panic(t *testing.T) {
+	var hookMsg string
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(bytes.NewBuffer(nil), 0), logOption.Level(level.Error),
+		logOption.OnFatal(logOption.FatalPanic),
+		logOption.FatalHook(func(msg string) {
+			hookMsg = msg
+		}))
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("The code did not panic")
+		}
+
+		if hookMsg != "panic message" {
+			t.Errorf("unexpected hook message = %s", hookMsg)
+		}
+	}()
+
+	log.Fatal("panic message")
+}
+
+func TestFatal_None(t *testing.T) {
+	restore := nlogger.SetExitFunc(func(code int) {
+		t.Errorf("exit must not be called")
+	})
+	defer restore()
+
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(bytes.NewBuffer(nil), 0))
+	log.Fatal("this must not exit")
+}
+
+func TestFatal_OverrideInCall(t *testing.T) {
+	exitCode := -1
+	restore := nlogger.SetExitFunc(func(code int) {
+		exitCode = code
+	})
+	defer restore()
+
+	hookCalled := false
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(bytes.NewBuffer(nil), 0), logOption.OnFatal(logOption.FatalExit),
+		logOption.FatalHook(func(string) { hookCalled = true }))
+
+	log.Fatal("print only", logOption.OnFatal(logOption.FatalNone), logOption.FatalHook(nil))
+	if exitCode != -1 || hookCalled {
+		t.Errorf("expected fatal behaviour is overridden by log call")
+	}
+
+	log.Fatal("exit", logOption.FatalExitCode(3))
+	if exitCode != 3 || !hookCalled {
+		t.Errorf("unexpected fatal behaviour, exit code = %d, hook called = %v", exitCode, hookCalled)
+	}
+}
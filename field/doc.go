@@ -0,0 +1,6 @@
+// Package logField provides typed fields of log entry, e.g.
+//
+//	log.Log(level.Info, "request handled", logField.String("method", "GET"), logField.Duration("elapsed", d))
+//
+// Fields are printed in the order they are added.
+package logField
@@ -0,0 +1,207 @@
+package logField
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Type defines how value of Field is stored
+type Type uint8
+
+// Type constants
+const (
+	// UnknownType is the zero value of Type. Field with unknown type is not printed
+	UnknownType Type = iota
+	// StringType stores value in String
+	StringType
+	// IntType stores value in Integer
+	IntType
+	// UintType stores value in Integer as bits of uint64
+	UintType
+	// FloatType stores value in Integer as bits of float64
+	FloatType
+	// BoolType stores value in Integer, 1 is true
+	BoolType
+	// DurationType stores value in Integer as nanoseconds
+	DurationType
+	// TimeType stores unix nano in Integer and location in Interface. If time is out of range of unix nano,
+	// then time.Time is stored in Interface
+	TimeType
+	// ErrorType stores error in Interface
+	ErrorType
+	// StringerType stores fmt.Stringer in Interface
+	StringerType
+	// AnyType stores value in Interface
+	AnyType
+	// LazyType stores func() interface{} in Interface. The function is called when entry is printed
+	LazyType
+)
+
+// DefaultErrorKey is the key of field created by Err
+const DefaultErrorKey = "error"
+
+// Field is a typed key value pair of log entry. Primitive values are stored without boxing into interface{},
+// so creating a Field does not allocate
+type Field struct {
+	Key       string
+	Type      Type
+	Integer   int64
+	String    string
+	Interface interface{}
+}
+
+// Value returns value of field
+func (f Field) Value() interface{} {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case IntType:
+		return f.Integer
+	case UintType:
+		return uint64(f.Integer)
+	case FloatType:
+		return math.Float64frombits(uint64(f.Integer))
+	case BoolType:
+		return f.Integer == 1
+	case DurationType:
+		return time.Duration(f.Integer)
+	case TimeType:
+		if t, ok := f.Interface.(time.Time); ok {
+			return t
+		}
+		t := time.Unix(0, f.Integer)
+		if loc, ok := f.Interface.(*time.Location); ok {
+			t = t.In(loc)
+		}
+		return t
+	case StringerType:
+		if f.Interface == nil {
+			return nil
+		}
+		return f.Interface.(fmt.Stringer).String()
+	case LazyType:
+		return f.Resolve().Value()
+	default:
+		return f.Interface
+	}
+}
+
+// Resolve returns field with computed value if field is lazy, otherwise it returns f
+func (f Field) Resolve() Field {
+	if f.Type != LazyType {
+		return f
+	}
+
+	fn, _ := f.Interface.(func() interface{})
+	if fn == nil {
+		return Field{Key: f.Key, Type: AnyType}
+	}
+	return Any(f.Key, fn())
+}
+
+// String creates a field with string value
+func String(key string, val string) Field {
+	return Field{Key: key, Type: StringType, String: val}
+}
+
+// Int creates a field with int value
+func Int(key string, val int) Field {
+	return Field{Key: key, Type: IntType, Integer: int64(val)}
+}
+
+// Int64 creates a field with int64 value
+func Int64(key string, val int64) Field {
+	return Field{Key: key, Type: IntType, Integer: val}
+}
+
+// Uint creates a field with uint value
+func Uint(key string, val uint) Field {
+	return Field{Key: key, Type: UintType, Integer: int64(val)}
+}
+
+// Uint64 creates a field with uint64 value
+func Uint64(key string, val uint64) Field {
+	return Field{Key: key, Type: UintType, Integer: int64(val)}
+}
+
+// Float64 creates a field with float64 value
+func Float64(key string, val float64) Field {
+	return Field{Key: key, Type: FloatType, Integer: int64(math.Float64bits(val))}
+}
+
+// Bool creates a field with bool value
+func Bool(key string, val bool) Field {
+	var i int64
+	if val {
+		i = 1
+	}
+	return Field{Key: key, Type: BoolType, Integer: i}
+}
+
+// Duration creates a field with time.Duration value
+func Duration(key string, val time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(val)}
+}
+
+// minTime and maxTime is the range of time that can be represented as unix nano
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// Time creates a field with time.Time value
+func Time(key string, val time.Time) Field {
+	if val.Before(minTime) || val.After(maxTime) {
+		return Field{Key: key, Type: TimeType, Interface: val}
+	}
+	return Field{Key: key, Type: TimeType, Integer: val.UnixNano(), Interface: val.Location()}
+}
+
+// Err creates a field with error value and DefaultErrorKey as key
+func Err(err error) Field {
+	return NamedErr(DefaultErrorKey, err)
+}
+
+// NamedErr creates a field with error value
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Stringer creates a field with value of fmt.Stringer. String is called when field is printed
+func Stringer(key string, val fmt.Stringer) Field {
+	return Field{Key: key, Type: StringerType, Interface: val}
+}
+
+// Any creates a field with any value. If value type is supported by typed constructors, then it is stored as typed
+func Any(key string, val interface{}) Field {
+	switch v := val.(type) {
+	case string:
+		return String(key, v)
+	case int:
+		return Int(key, v)
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint(key, v)
+	case uint64:
+		return Uint64(key, v)
+	case float64:
+		return Float64(key, v)
+	case bool:
+		return Bool(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case error:
+		return NamedErr(key, v)
+	default:
+		return Field{Key: key, Type: AnyType, Interface: val}
+	}
+}
+
+// Lazy creates a field that its value is computed by fn only when entry is printed
+func Lazy(key string, fn func() interface{}) Field {
+	return Field{Key: key, Type: LazyType, Interface: fn}
+}
@@ -0,0 +1,192 @@
+package nlogger
+
+import (
+	"encoding/json"
+	"fmt"
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"strconv"
+	"time"
+)
+
+// FieldLogger is implemented by Logger that supports typed fields
+type FieldLogger interface {
+	// Log must write a message with typed fields in level lv. Fields must be evaluated only if level is enabled
+	Log(lv level.LogLevel, msg string, fields ...logField.Field)
+}
+
+// Log writes a message with typed fields. If level is disabled, it returns without allocation
+func (l *StdLogger) Log(lv level.LogLevel, msg string, fields ...logField.Field) {
+	if lv != level.Fatal && !l.level.Enabled(lv) {
+		return
+	}
+	l.print(lv, msg, fieldOptions(fields))
+}
+
+// fieldOptions creates options with copy of fields
+func fieldOptions(fields []logField.Field) *logOption.Options {
+	o := logOption.NewOptions()
+	o.Fields = copyFields(fields)
+	return o
+}
+
+// copyFields returns copy of fields, so fields slice of caller does not escape to heap
+func copyFields(fields []logField.Field) []logField.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+	c := make([]logField.Field, len(fields))
+	copy(c, fields)
+	return c
+}
+
+// mergeFields returns a new slice of parent fields followed by child fields. Since fields with duplicated key
+// keep the last value when printed, child fields override parent fields
+func mergeFields(parent, child []logField.Field) []logField.Field {
+	if len(parent)+len(child) == 0 {
+		return nil
+	}
+
+	result := make([]logField.Field, 0, len(parent)+len(child))
+	result = append(result, parent...)
+	return append(result, child...)
+}
+
+// mergeMetadata returns a new map of parent metadata overridden by child metadata
+func mergeMetadata(parent, child map[string]interface{}) map[string]interface{} {
+	if len(parent)+len(child) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(parent)+len(child))
+	for k, v := range parent {
+		result[k] = v
+	}
+	for k, v := range child {
+		result[k] = v
+	}
+	return result
+}
+
+// uniqueFields returns fields with distinct key. If key is duplicated, value of the last field is kept
+// at the position of the first field. Fields with unknown type or empty key are removed
+func uniqueFields(fields []logField.Field) []logField.Field {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	result := make([]logField.Field, 0, len(fields))
+	index := make(map[string]int, len(fields))
+	for _, f := range fields {
+		if f.Key == "" || f.Type == logField.UnknownType {
+			continue
+		}
+		if i, ok := index[f.Key]; ok {
+			result[i] = f
+			continue
+		}
+		index[f.Key] = len(result)
+		result = append(result, f)
+	}
+	return result
+}
+
+// fieldValue returns value of field to be printed. Time is formatted with configured time format and
+// duration is formatted as string
+func (o *printerOptions) fieldValue(f logField.Field) interface{} {
+	switch f.Type {
+	case logField.TimeType:
+		return o.formatTime(f.Value().(time.Time))
+	case logField.DurationType:
+		return time.Duration(f.Integer).String()
+	case logField.ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			return err.Error()
+		}
+		return nil
+	default:
+		return f.Value()
+	}
+}
+
+// appendJSONFields appends fields as members of JSON object b. Fields that its key exists in skip are not written
+func (o *printerOptions) appendJSONFields(b []byte, fields []logField.Field, skip map[string]interface{}) []byte {
+	if len(fields) == 0 {
+		return b
+	}
+
+	// Remove closing brace
+	b = b[:len(b)-1]
+	for _, f := range fields {
+		if _, ok := skip[f.Key]; ok {
+			continue
+		}
+
+		if len(b) > 1 {
+			b = append(b, ',')
+		}
+		k, _ := json.Marshal(f.Key)
+		b = append(b, k...)
+		b = append(b, ':')
+		b = o.appendJSONValue(b, f)
+	}
+	return append(b, '}')
+}
+
+// appendJSONValue appends JSON encoded value of field. If value is not serializable, then it is written as string
+func (o *printerOptions) appendJSONValue(b []byte, f logField.Field) []byte {
+	switch f.Type {
+	case logField.IntType:
+		return strconv.AppendInt(b, f.Integer, 10)
+	case logField.UintType:
+		return strconv.AppendUint(b, uint64(f.Integer), 10)
+	case logField.BoolType:
+		return strconv.AppendBool(b, f.Integer == 1)
+	}
+
+	v, err := json.Marshal(o.fieldValue(f))
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprintf("%+v", f.Value()))
+	}
+	return append(b, v...)
+}
+
+// logFields writes typed fields to Logger that does not implement FieldLogger
+func logFields(l Logger, lv level.LogLevel, msg string, fields []logField.Field) {
+	args := logOption.Fields(copyFields(fields)...)
+	switch lv {
+	case level.Fatal:
+		l.Fatal(msg, args)
+	case level.Error:
+		l.Error(msg, args)
+	case level.Warn:
+		l.Warn(msg, args)
+	case level.Info:
+		l.Info(msg, args)
+	case level.Debug:
+		l.Debug(msg, args)
+	default:
+		l.Trace(msg, args)
+	}
+}
+
+// Log writes a message with typed fields to l. If l does not implement FieldLogger, then fields are set
+// with logOption.Fields
+func Log(l Logger, lv level.LogLevel, msg string, fields ...logField.Field) {
+	if p, ok := l.(*proxyLogger); ok {
+		l = p.resolve()
+	}
+
+	// Call StdLogger.print directly, so the caller capture depth is the same as calling StdLogger methods
+	switch t := l.(type) {
+	case *StdLogger:
+		if lv == level.Fatal || t.level.Enabled(lv) {
+			t.print(lv, msg, fieldOptions(fields))
+		}
+	case FieldLogger:
+		t.Log(lv, msg, copyFields(fields)...)
+	default:
+		logFields(l, lv, msg, fields)
+	}
+}
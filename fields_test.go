@@ -0,0 +1,208 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/nbs-go/nlogger/v2"
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestField_Value(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := errors.New("failed")
+
+	testCases := []struct {
+		field    logField.Field
+		expected interface{}
+	}{
+		{logField.String("k", "v"), "v"},
+		{logField.Int("k", -1), int64(-1)},
+		{logField.Uint64("k", math.MaxUint64), uint64(math.MaxUint64)},
+		{logField.Float64("k", 1.5), 1.5},
+		{logField.Bool("k", true), true},
+		{logField.Duration("k", time.Second), time.Second},
+		{logField.Time("k", ts), ts},
+		{logField.Time("k", time.Time{}), time.Time{}},
+		{logField.Err(err), err},
+		{logField.Stringer("k", time.Second), "1s"},
+		{logField.Any("k", "v"), "v"},
+		{logField.Any("k", []int{1}), []int{1}},
+	}
+
+	for _, tc := range testCases {
+		if v := tc.field.Value(); !reflect.DeepEqual(v, tc.expected) {
+			t.Errorf("unexpected value of %v = %v", tc.field, v)
+		}
+	}
+
+	if f := logField.Any("k", 1); f.Type != logField.IntType {
+		t.Errorf("expected Any stores int as typed field")
+	}
+	if f := logField.Err(err); f.Key != logField.DefaultErrorKey {
+		t.Errorf("unexpected error key = %s", f.Key)
+	}
+}
+
+func TestLog_JSONFields(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimestampKey("")), logOption.Level(level.Info))
+
+	log.Log(level.Info, "handled",
+		logField.String("method", "GET"),
+		logField.Int("status", 200),
+		logField.Duration("elapsed", 1500*time.Millisecond),
+		logField.Bool("cached", false),
+		logField.Any("tags", []string{"a"}),
+		logField.String("level", "ignored"),
+	)
+
+	expected := `{"level":"Info","message":"handled","method":"GET","status":200,"elapsed":"1.5s","cached":false,"tags":["a"]}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
+
+func TestLog_FieldsOverrideMetadata(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimestampKey("")), logOption.Level(level.Info))
+
+	log.Info("override",
+		logOption.AddMetadata("user", "meta"),
+		logOption.AddMetadata("other", 1),
+		logOption.Fields(logField.String("user", "field"), logField.Int("n", 1), logField.Int("n", 2)),
+	)
+
+	expected := `{"level":"Info","message":"override","other":1,"user":"field","n":2}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
+
+func TestLog_LogfmtFields(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewLogfmtPrinter(buf, nlogger.WithTimestampKey("")), logOption.Level(level.Info))
+
+	log.Log(level.Warn, "slow",
+		logField.String("z", "first"),
+		logField.Any("user", map[string]interface{}{"id": 1}),
+		logField.Err(errors.New("timed out")),
+		logField.Float64("ratio", 0.5),
+	)
+
+	expected := `level=Warn msg=slow z=first user.id=1 error="timed out" ratio=0.5` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
+
+func TestLog_StdFields(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Info))
+
+	log.Log(level.Info, "fields", logField.String("b", "1"), logField.Int("a", 2))
+
+	expected := " [INFO] fields\n  > Metadata: {\"b\":\"1\",\"a\":2}\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+}
+
+func TestLog_Proxy(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	buf := bytes.NewBuffer(nil)
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewLogfmtPrinter(buf, nlogger.WithTimestampKey("")),
+		logOption.Level(level.Info)))
+
+	nlogger.Log(nlogger.Get(), level.Info, "proxy", logField.Int("n", 1))
+	nlogger.Log(nlogger.Get(), level.Debug, "disabled", logField.Int("n", 2))
+
+	if expected := "level=Info msg=proxy n=1\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+}
+
+func TestLog_DisabledZeroAlloc(t *testing.T) {
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(io.Discard), logOption.Level(level.Info))
+	err := errors.New("failed")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Log(level.Debug, "disabled",
+			logField.String("method", "GET"),
+			logField.Int("status", 200),
+			logField.Duration("elapsed", time.Millisecond),
+			logField.Err(err),
+		)
+	})
+	if allocs != 0 {
+		t.Errorf("expected disabled level is zero allocation, got %v", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		log.Debug("disabled")
+	})
+	if allocs != 0 {
+		t.Errorf("expected disabled level is zero allocation, got %v", allocs)
+	}
+}
+
+func TestLog_Caller(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewLogfmtPrinter(buf), logOption.Level(level.Info), logOption.WithCaller())
+
+	log.Log(level.Info, "caller")
+	nlogger.Log(log, level.Info, "caller")
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if !strings.Contains(line, "fields_test.go") {
+			t.Errorf("unexpected caller = %s", line)
+		}
+	}
+}
+
+func BenchmarkLog_Disabled(b *testing.B) {
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(io.Discard), logOption.Level(level.Info))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Log(level.Debug, "disabled", logField.String("method", "GET"), logField.Int("status", 200))
+	}
+}
+
+func BenchmarkLog_Enabled(b *testing.B) {
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(io.Discard), logOption.Level(level.Info))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Log(level.Info, "enabled", logField.String("method", "GET"), logField.Int("status", 200))
+	}
+}
+
+func BenchmarkLog_DisabledProxy(b *testing.B) {
+	restore := nlogger.Replace(nlogger.NewStdLogger(nlogger.NewJSONPrinter(io.Discard), logOption.Level(level.Info)))
+	defer restore()
+
+	log := nlogger.NewChild(logOption.WithNamespace("bench"))
+	method, start := "GET", time.Now()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nlogger.Log(log, level.Debug, "disabled", logField.String("method", method), logField.Int("status", i),
+			logField.Duration("elapsed", time.Since(start)))
+	}
+}
+
+func BenchmarkInfo_DisabledMetadata(b *testing.B) {
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(io.Discard), logOption.Level(level.Warn))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Info("disabled", logOption.AddMetadata("method", "GET"), logOption.AddMetadata("status", 200))
+	}
+}
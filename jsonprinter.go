@@ -0,0 +1,115 @@
+package nlogger
+
+import (
+	"encoding/json"
+	logContext "github.com/nbs-go/nlogger/v2/context"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"os"
+	"sync"
+)
+
+// NewJSONPrinter creates a Printer that writes a JSON object per log entry.
+// Metadata is merged into the root object, built-in fields take precedence on key conflicts
+func NewJSONPrinter(out io.Writer, args ...PrinterOption) *jsonPrinter {
+	// If writer is nil, set default writer to Stdout
+	if out == nil {
+		out = os.Stdout
+	}
+
+	return &jsonPrinter{
+		out:     out,
+		options: newPrinterOptions(args),
+	}
+}
+
+type jsonPrinter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	options *printerOptions
+}
+
+// Sync flushes writer if supported
+func (p *jsonPrinter) Sync() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return syncWriter(p.out)
+}
+
+func (p *jsonPrinter) Print(namespace string, lv level.LogLevel, msg string, options *logOption.Options) {
+	o := p.options
+	entry := make(map[string]interface{}, 6)
+
+	// Set built-in fields
+	setField(entry, o.timestampKey, o.formatTime(entryTime(options)))
+	setField(entry, o.levelKey, level.String(lv))
+	if namespace != "" {
+		setField(entry, o.namespaceKey, namespace)
+	}
+	setField(entry, o.messageKey, formatMessage(msg, options))
+
+	// Get request id
+	if reqId := logContext.GetRequestId(options.Context); reqId != "" {
+		setField(entry, o.requestIdKey, reqId)
+	}
+
+	// Get caller
+	if c, ok := logOption.GetCaller(options, logOption.CallerKey); ok {
+		setField(entry, o.callerKey, c)
+	}
+
+	// Get error
+	if logErr := logOption.GetError(options, logOption.ErrorKey); logErr != nil {
+		setField(entry, o.errorKey, logErr.Error())
+
+		// Set error chain if error wraps other errors
+		if chain := logOption.ErrorChain(logErr); len(chain) > 1 {
+			setField(entry, o.errorChainKey, chain)
+		}
+	}
+
+	// Get stack trace
+	if stack, _ := logOption.GetString(options, logOption.StackKey); stack != "" {
+		setField(entry, o.stackKey, stack)
+	}
+
+	// Merge metadata, typed fields and built-in fields take precedence
+	meta := o.metadata(options)
+	fields := uniqueFields(options.Fields)
+	body := make(map[string]interface{}, len(meta)+len(entry))
+	for k, v := range meta {
+		body[k] = v
+	}
+	for _, f := range fields {
+		delete(body, f.Key)
+	}
+	for k, v := range entry {
+		body[k] = v
+	}
+
+	// Serialize to json
+	b, err := json.Marshal(body)
+	if err != nil {
+		// If metadata is not serializable, then print built-in fields only
+		entry["metadataError"] = err.Error()
+		b, _ = json.Marshal(entry)
+	}
+
+	// Append typed fields in the order they are added
+	b = o.appendJSONFields(b, fields, entry)
+	b = append(b, '\n')
+
+	// Write entry at once, so entries will not be interleaved
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = p.out.Write(b)
+}
+
+// setField set value to map if key is not empty
+func setField(m map[string]interface{}, k string, v interface{}) {
+	if k == "" {
+		return
+	}
+	m[k] = v
+}
@@ -0,0 +1,102 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nbs-go/nlogger/v2"
+	logContext "github.com/nbs-go/nlogger/v2/context"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"testing"
+)
+
+func TestJSONPrinter(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	testLogger := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf), logOption.Level(level.Debug),
+		logOption.WithNamespace("json"))
+
+	// Use its own map, since AddMetadata writes to the map that is set by Metadata
+	meta := map[string]interface{}{
+		"string":  "string",
+		"integer": 0,
+	}
+
+	ctx := logContext.SetRequestId(context.Background(), "req-1")
+	testLogger.Error("Testing JSON with options: %s",
+		logOption.Error(errors.New("a error occurred")),
+		logOption.Context(ctx),
+		logOption.Metadata(meta),
+		logOption.AddMetadata("level", "must be overridden"),
+		logOption.Format("arg1"),
+	)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"level":     "Error",
+		"namespace": "json",
+		"message":   "Testing JSON with options: arg1",
+		"requestId": "req-1",
+		"error":     "a error occurred",
+		"string":    "string",
+	}
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("unexpected %s value. Expected = %v, Actual = %v", k, v, entry[k])
+		}
+	}
+
+	if _, ok := entry["timestamp"]; !ok {
+		t.Errorf("timestamp is not printed")
+	}
+}
+
+func TestJSONPrinter_CustomKeys(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewJSONPrinter(buf,
+		nlogger.WithTimestampKey("@timestamp"),
+		nlogger.WithLevelKey("severity"),
+		nlogger.WithMessageKey("msg"),
+		nlogger.WithNamespaceKey(""),
+		nlogger.WithTimeFormat(nlogger.TimeFormatUnix),
+	)
+	testLogger := nlogger.NewStdLogger(p, logOption.Level(level.Info), logOption.WithNamespace("json"))
+	testLogger.Infof("Hello %s", "World")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	if entry["severity"] != "Info" || entry["msg"] != "Hello World" {
+		t.Errorf("unexpected entry = %v", entry)
+	}
+
+	if _, ok := entry["@timestamp"].(float64); !ok {
+		t.Errorf("unexpected timestamp value = %v", entry["@timestamp"])
+	}
+
+	if _, ok := entry["namespace"]; ok {
+		t.Errorf("namespace must not be printed")
+	}
+}
+
+func TestJSONPrinter_InvalidMetadata(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	testLogger := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf), logOption.Level(level.Info))
+	testLogger.Info("invalid metadata", logOption.AddMetadata("chan", make(chan int)))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	if _, ok := entry["metadataError"]; !ok {
+		t.Errorf("metadataError is not printed")
+	}
+}
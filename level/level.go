@@ -1,6 +1,9 @@
 package level
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // LogLevel constants as defined in RFC5424.
 type LogLevel = int8
@@ -23,22 +26,72 @@ const (
 
 // Parse parse string value to level.LogLevel
 func Parse(level string) LogLevel {
+	lv, _ := TryParse(level)
+	return lv
+}
+
+// TryParse parse string value to level.LogLevel. If value is not a valid level, then it returns Default and false
+func TryParse(level string) (LogLevel, bool) {
 	switch strings.ToLower(level) {
 	case "panic", "0", "fatal", "1":
-		return Fatal
+		return Fatal, true
+	case "error", "3":
+		return Error, true
 	case "warn", "4":
-		return Warn
+		return Warn, true
 	case "info", "6":
-		return Info
+		return Info, true
 	case "debug", "7":
-		return Debug
+		return Debug, true
 	case "trace", "8":
-		return Trace
+		return Trace, true
 	default:
-		return Default
+		return Default, false
 	}
 }
 
+// ParseSpec parse level specification that contains default level and per-namespace level overrides,
+// e.g. "info,db=debug,http.client=trace". Items without namespace set the default level. Invalid levels are
+// parsed as Default
+func ParseSpec(spec string) (LogLevel, map[string]LogLevel) {
+	lv, namespaces, _ := TryParseSpec(spec)
+	return lv, namespaces
+}
+
+// TryParseSpec parse level specification as ParseSpec. If specification contains invalid level, then it returns
+// error of the first invalid item
+func TryParseSpec(spec string) (LogLevel, map[string]LogLevel, error) {
+	defaultLevel := Default
+	namespaces := make(map[string]LogLevel)
+	var err error
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		// If namespace is not set, then set default level
+		namespace, value := "", item
+		if i := strings.LastIndex(item, "="); i >= 0 {
+			namespace, value = strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+		}
+
+		lv, ok := TryParse(value)
+		if !ok && err == nil {
+			err = fmt.Errorf("invalid level %q in level specification", item)
+		}
+
+		if namespace == "" {
+			defaultLevel = lv
+		} else {
+			namespaces[namespace] = lv
+		}
+	}
+
+	return defaultLevel, namespaces, err
+}
+
 func String(l LogLevel) string {
 	switch l {
 	case Fatal:
@@ -0,0 +1,59 @@
+package level
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// unset is a sentinel value that marks a Var to inherit level from its parent
+const unset = math.MinInt32
+
+// Var holds a LogLevel that can be changed at runtime. It is safe for concurrent use,
+// so a Var can be shared by reference between loggers
+type Var struct {
+	v      int32
+	parent *Var
+}
+
+// NewVar creates a Var with initial level
+func NewVar(lv LogLevel) *Var {
+	return &Var{v: int32(lv)}
+}
+
+// NewChild creates a Var that inherits level from v until its level is set
+func (v *Var) NewChild() *Var {
+	return &Var{v: unset, parent: v}
+}
+
+// Get returns current level. If level is not set, then returns level of parent
+func (v *Var) Get() LogLevel {
+	for c := v; c != nil; c = c.parent {
+		if lv := atomic.LoadInt32(&c.v); lv != unset {
+			return LogLevel(lv)
+		}
+	}
+	return Default
+}
+
+// Set change current level
+func (v *Var) Set(lv LogLevel) {
+	atomic.StoreInt32(&v.v, int32(lv))
+}
+
+// IsSet returns true if level is set and not inherited from parent
+func (v *Var) IsSet() bool {
+	return v.parent == nil || atomic.LoadInt32(&v.v) != unset
+}
+
+// Unset clear level, so v will inherit level from parent. It has no effect on Var without parent
+func (v *Var) Unset() {
+	if v.parent == nil {
+		return
+	}
+	atomic.StoreInt32(&v.v, unset)
+}
+
+// Enabled returns true if output level is printed by current level
+func (v *Var) Enabled(outLevel LogLevel) bool {
+	return outLevel <= v.Get()
+}
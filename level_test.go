@@ -60,3 +60,42 @@ func TestString_Unknown(t *testing.T) {
 		t.Errorf("unexpected %s string value = %s", exp, str)
 	}
 }
+
+func TestParseSpec(t *testing.T) {
+	lv, namespaces := level.ParseSpec("info, db=debug,http.client=TRACE,=warn")
+	if lv != level.Warn {
+		t.Errorf("unexpected default level = %d", lv)
+	}
+
+	expected := map[string]level.LogLevel{
+		"db":          level.Debug,
+		"http.client": level.Trace,
+	}
+	if len(namespaces) != len(expected) {
+		t.Errorf("unexpected namespace levels = %v", namespaces)
+	}
+	for k, v := range expected {
+		if namespaces[k] != v {
+			t.Errorf("unexpected %s level. Expected = %d, Actual = %d", k, v, namespaces[k])
+		}
+	}
+
+	// Parse single level
+	lv, namespaces = level.ParseSpec("debug")
+	if lv != level.Debug || len(namespaces) != 0 {
+		t.Errorf("unexpected result. Level = %d, Namespaces = %v", lv, namespaces)
+	}
+}
+
+func TestTryParseSpec(t *testing.T) {
+	lv, namespaces, err := level.TryParseSpec("info,db=debug")
+	if err != nil || lv != level.Info || namespaces["db"] != level.Debug {
+		t.Errorf("unexpected result. Level = %d, Namespaces = %v, Error = %v", lv, namespaces, err)
+	}
+
+	for _, spec := range []string{"debgu", "info,db=verbose", "db="} {
+		if _, _, err = level.TryParseSpec(spec); err == nil {
+			t.Errorf("expected error when parsing %q", spec)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package nlogger
+
+import (
+	"github.com/nbs-go/nlogger/v2/level"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// namespaceLevels holds level overrides of namespaces in a logger tree. Level of namespace without override is
+// inherited from its nearest parent namespace with override in dotted hierarchy, or from root level
+type namespaceLevels struct {
+	mu   sync.RWMutex
+	root *level.Var
+	// vars only contains namespaces that have level override
+	vars map[string]*level.Var
+	// version is incremented every time an override is added or removed
+	version uint64
+}
+
+func newNamespaceLevels(root *level.Var) *namespaceLevels {
+	return &namespaceLevels{
+		root: root,
+		vars: make(map[string]*level.Var),
+	}
+}
+
+// levelOf returns level holder of namespace. If namespace is empty, then it holds root level
+func (n *namespaceLevels) levelOf(namespace string) *namespaceLevel {
+	return &namespaceLevel{levels: n, namespace: namespace}
+}
+
+// set override level of namespace. If namespace is empty, then root level is changed
+func (n *namespaceLevels) set(namespace string, lv level.LogLevel) {
+	if namespace == "" {
+		n.root.Set(lv)
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if v, ok := n.vars[namespace]; ok {
+		v.Set(lv)
+		return
+	}
+	n.vars[namespace] = level.NewVar(lv)
+	atomic.AddUint64(&n.version, 1)
+}
+
+// unset clear level override of namespace, so it will inherit level from its parent
+func (n *namespaceLevels) unset(namespace string) {
+	if namespace == "" {
+		n.root.Unset()
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.vars[namespace]; !ok {
+		return
+	}
+	delete(n.vars, namespace)
+	atomic.AddUint64(&n.version, 1)
+}
+
+// get returns effective level of namespace and whether the level is overridden
+func (n *namespaceLevels) get(namespace string) (level.LogLevel, bool) {
+	if namespace == "" {
+		return n.root.Get(), n.root.IsSet()
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if v, ok := n.vars[namespace]; ok {
+		return v.Get(), true
+	}
+	return n.lookup(namespace).Get(), false
+}
+
+// lookup returns level holder of namespace or its nearest parent namespace that has override.
+// Caller must hold the lock
+func (n *namespaceLevels) lookup(namespace string) *level.Var {
+	for namespace != "" {
+		if v, ok := n.vars[namespace]; ok {
+			return v
+		}
+
+		i := strings.LastIndex(namespace, ".")
+		if i <= 0 {
+			break
+		}
+		namespace = namespace[:i]
+	}
+	return n.root
+}
+
+// all returns effective level of each namespace that has level override
+func (n *namespaceLevels) all() map[string]level.LogLevel {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	result := make(map[string]level.LogLevel, len(n.vars))
+	for k, v := range n.vars {
+		result[k] = v.Get()
+	}
+	return result
+}
+
+// namespaceLevel is the level of loggers in a namespace. Level holder that is resolved from overrides is cached
+// until an override is added or removed
+type namespaceLevel struct {
+	levels    *namespaceLevels
+	namespace string
+	cache     atomic.Value
+}
+
+// levelCache holds resolved level holder of a namespace levels version
+type levelCache struct {
+	version uint64
+	v       *level.Var
+}
+
+// resolve returns level holder of namespace or its nearest parent namespace that has override
+func (l *namespaceLevel) resolve() *level.Var {
+	if l.namespace == "" {
+		return l.levels.root
+	}
+
+	version := atomic.LoadUint64(&l.levels.version)
+	if c, ok := l.cache.Load().(*levelCache); ok && c.version == version {
+		return c.v
+	}
+
+	l.levels.mu.RLock()
+	v := l.levels.lookup(l.namespace)
+	l.levels.mu.RUnlock()
+
+	l.cache.Store(&levelCache{version: version, v: v})
+	return v
+}
+
+// Get returns current level
+func (l *namespaceLevel) Get() level.LogLevel {
+	return l.resolve().Get()
+}
+
+// Set override level of namespace
+func (l *namespaceLevel) Set(lv level.LogLevel) {
+	l.levels.set(l.namespace, lv)
+}
+
+// Enabled returns true if output level is printed by current level
+func (l *namespaceLevel) Enabled(outLevel level.LogLevel) bool {
+	return outLevel <= l.resolve().Get()
+}
@@ -0,0 +1,81 @@
+package nlogger
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Syncer is implemented by Logger, Printer or writer that buffers entries and can flush them
+type Syncer interface {
+	Sync() error
+}
+
+// Closer is implemented by Logger or Printer that holds resources which must be released on shutdown.
+// Implementation of io.Closer is also closed by Shutdown
+type Closer interface {
+	Close(ctx context.Context) error
+}
+
+// Sync flushes entries buffered by registered logger and its printers
+func Sync() error {
+	log.mu.RLock()
+	l := log.logger
+	log.mu.RUnlock()
+
+	if l == nil {
+		return nil
+	}
+	return syncOf(Unwrap(l))
+}
+
+// Shutdown flushes startup buffer, then flushes and closes registered logger and its printers. Registered logger
+// is cleared, so entries after shutdown are written to fallback logger
+func Shutdown(ctx context.Context) error {
+	FlushStartupBuffer()
+
+	log.mu.Lock()
+	l := log.logger
+	log.logger = nil
+	log.generation++
+	log.mu.Unlock()
+
+	if l == nil {
+		return nil
+	}
+
+	l = Unwrap(l)
+	err := syncOf(l)
+	if closeErr := closeOf(ctx, l); closeErr != nil {
+		err = closeErr
+	}
+	return err
+}
+
+// syncOf calls Sync if v is a Syncer
+func syncOf(v interface{}) error {
+	if s, ok := v.(Syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// closeOf calls Close if v is a Closer or io.Closer
+func closeOf(ctx context.Context, v interface{}) error {
+	switch c := v.(type) {
+	case Closer:
+		return c.Close(ctx)
+	case io.Closer:
+		return c.Close()
+	}
+	return nil
+}
+
+// syncWriter flushes writer if supported. Standard output and error are skipped, since sync is not supported
+// on terminal or pipe
+func syncWriter(w io.Writer) error {
+	if w == os.Stdout || w == os.Stderr {
+		return nil
+	}
+	return syncOf(w)
+}
@@ -0,0 +1,152 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"reflect"
+	"testing"
+)
+
+// lifecyclePrinter records entries, Sync and Close calls
+type lifecyclePrinter struct {
+	gatedPrinter
+	syncs  int
+	closes int
+}
+
+func newLifecyclePrinter() *lifecyclePrinter {
+	p := lifecyclePrinter{}
+	p.started = make(chan struct{}, 1000)
+	p.release = make(chan struct{})
+	close(p.release)
+	return &p
+}
+
+func (p *lifecyclePrinter) Sync() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.syncs++
+	return nil
+}
+
+func (p *lifecyclePrinter) Close(context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closes++
+	return nil
+}
+
+// syncBuffer is a writer that counts Sync calls
+type syncBuffer struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (b *syncBuffer) Sync() error {
+	b.syncs++
+	return nil
+}
+
+// closerLogger is a Logger that implements io.Closer
+type closerLogger struct {
+	*nlogger.StdLogger
+	closed bool
+}
+
+func (l *closerLogger) Close() error {
+	l.closed = true
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	// No logger registered
+	if err := nlogger.Sync(); err != nil {
+		t.Errorf("unexpected error = %s", err)
+	}
+
+	p := newLifecyclePrinter()
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewAsyncPrinter(p), logOption.Level(level.Info)))
+
+	log := nlogger.Get().NewChild(logOption.WithNamespace("child"))
+	for i := 0; i < 10; i++ {
+		log.Info("entry")
+	}
+
+	if err := nlogger.Sync(); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	if n := len(p.Messages()); n != 10 {
+		t.Errorf("expected entries are flushed, got %d", n)
+	}
+	if p.syncs != 1 {
+		t.Errorf("unexpected sync count = %d", p.syncs)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	p := newLifecyclePrinter()
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewAsyncPrinter(p), logOption.Level(level.Info)))
+	nlogger.Get().Info("before shutdown")
+
+	if err := nlogger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	if m := p.Messages(); !reflect.DeepEqual(m, []string{"before shutdown"}) {
+		t.Errorf("unexpected printed messages = %v", m)
+	}
+	if p.closes != 1 {
+		t.Errorf("unexpected close count = %d", p.closes)
+	}
+
+	// Registered logger is cleared
+	nlogger.Get().Info("after shutdown")
+	if m := p.Messages(); len(m) != 1 {
+		t.Errorf("expected entry after shutdown is not printed to closed logger, got %v", m)
+	}
+}
+
+func TestShutdown_IOCloser(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	l := &closerLogger{StdLogger: nlogger.NewStdLogger(nlogger.NewStdLogPrinter(&bytes.Buffer{}, 0))}
+	nlogger.Register(l)
+
+	if err := nlogger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if !l.closed {
+		t.Errorf("expected logger is closed")
+	}
+}
+
+func TestPrinter_Sync(t *testing.T) {
+	buf := &syncBuffer{}
+	printers := []nlogger.Printer{
+		nlogger.NewStdLogPrinter(buf, 0),
+		nlogger.NewJSONPrinter(buf),
+		nlogger.NewLogfmtPrinter(buf),
+		nlogger.NewSamplingPrinter(nlogger.NewJSONPrinter(buf), 0, 1, 0),
+	}
+
+	for _, p := range printers {
+		if err := nlogger.NewStdLogger(p).Sync(); err != nil {
+			t.Errorf("unexpected error = %s", err)
+		}
+	}
+
+	if buf.syncs != len(printers) {
+		t.Errorf("unexpected sync count = %d", buf.syncs)
+	}
+}
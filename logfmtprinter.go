@@ -0,0 +1,247 @@
+package nlogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	logContext "github.com/nbs-go/nlogger/v2/context"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NewLogfmtPrinter creates a Printer that writes log entry as key=value pairs in a single line.
+// Built-in fields are printed first, then followed by metadata sorted by key. Nested map, struct and
+// array in metadata are flattened into dotted keys
+func NewLogfmtPrinter(out io.Writer, args ...PrinterOption) *logfmtPrinter {
+	// If writer is nil, set default writer to Stdout
+	if out == nil {
+		out = os.Stdout
+	}
+
+	// Override default message key to follow logfmt convention
+	args = append([]PrinterOption{WithMessageKey("msg")}, args...)
+
+	return &logfmtPrinter{
+		out:     out,
+		options: newPrinterOptions(args),
+	}
+}
+
+type logfmtPrinter struct {
+	mu      sync.Mutex
+	out     io.Writer
+	options *printerOptions
+}
+
+// Sync flushes writer if supported
+func (p *logfmtPrinter) Sync() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return syncWriter(p.out)
+}
+
+func (p *logfmtPrinter) Print(namespace string, lv level.LogLevel, msg string, options *logOption.Options) {
+	o := p.options
+	buf := bytes.NewBuffer(nil)
+	builtIn := make(map[string]bool, 6)
+
+	// Write built-in fields
+	writeLogfmtField(buf, builtIn, o.timestampKey, o.formatTimeString(entryTime(options)))
+	writeLogfmtField(buf, builtIn, o.levelKey, level.String(lv))
+	if namespace != "" {
+		writeLogfmtField(buf, builtIn, o.namespaceKey, namespace)
+	}
+	writeLogfmtField(buf, builtIn, o.messageKey, formatMessage(msg, options))
+
+	// Get request id
+	if reqId := logContext.GetRequestId(options.Context); reqId != "" {
+		writeLogfmtField(buf, builtIn, o.requestIdKey, reqId)
+	}
+
+	// Get caller
+	if c, ok := logOption.GetCaller(options, logOption.CallerKey); ok && o.callerKey != "" {
+		writeLogfmtField(buf, builtIn, o.callerKey, c.String())
+		writeLogfmtField(buf, builtIn, o.callerKey+".function", c.Function)
+	}
+
+	// Get error
+	if logErr := logOption.GetError(options, logOption.ErrorKey); logErr != nil {
+		writeLogfmtField(buf, builtIn, o.errorKey, logErr.Error())
+
+		// Write error chain if error wraps other errors
+		if chain := logOption.ErrorChain(logErr); len(chain) > 1 && o.errorChainKey != "" {
+			for i, c := range chain {
+				prefix := o.errorChainKey + "." + strconv.Itoa(i)
+				writeLogfmtField(buf, builtIn, prefix+".type", c.Type)
+				writeLogfmtField(buf, builtIn, prefix+".message", c.Message)
+			}
+		}
+	}
+
+	// Get stack trace
+	if stack, _ := logOption.GetString(options, logOption.StackKey); stack != "" {
+		writeLogfmtField(buf, builtIn, o.stackKey, stack)
+	}
+
+	// Write typed fields in the order they are added
+	fields := uniqueFields(options.Fields)
+	fieldKeys := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if builtIn[f.Key] {
+			continue
+		}
+		fieldKeys[f.Key] = true
+
+		flat := make(map[string]string)
+		flattenLogfmtValue(flat, f.Key, o.fieldValue(f))
+		writeLogfmtFlat(buf, flat, builtIn)
+	}
+
+	// Flatten metadata and write in sorted order. Typed fields take precedence
+	if meta := o.metadata(options); len(meta) > 0 {
+		flat := make(map[string]string)
+		for k, v := range meta {
+			if fieldKeys[k] {
+				continue
+			}
+			flattenLogfmtValue(flat, k, v)
+		}
+		writeLogfmtFlat(buf, flat, builtIn)
+	}
+	buf.WriteByte('\n')
+
+	// Write entry at once, so entries will not be interleaved
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = p.out.Write(buf.Bytes())
+}
+
+// writeLogfmtFlat writes flattened pairs in sorted order. Built-in fields take precedence
+func writeLogfmtFlat(buf *bytes.Buffer, flat map[string]string, builtIn map[string]bool) {
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if builtIn[k] {
+			continue
+		}
+		writeLogfmtPair(buf, k, flat[k])
+	}
+}
+
+// flattenLogfmtValue normalize value by serializing it to json, then flatten the result into dotted keys
+func flattenLogfmtValue(dst map[string]string, prefix string, v interface{}) {
+	switch t := v.(type) {
+	case string:
+		dst[prefix] = t
+		return
+	case error:
+		dst[prefix] = t.Error()
+		return
+	case fmt.Stringer:
+		dst[prefix] = t.String()
+		return
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		dst[prefix] = fmt.Sprintf("%+v", v)
+		return
+	}
+
+	var normalized interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err = d.Decode(&normalized); err != nil {
+		dst[prefix] = string(b)
+		return
+	}
+
+	flattenLogfmtNormalized(dst, prefix, normalized)
+}
+
+func flattenLogfmtNormalized(dst map[string]string, prefix string, v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			dst[prefix] = "{}"
+		}
+		for k, cv := range t {
+			flattenLogfmtNormalized(dst, prefix+"."+k, cv)
+		}
+	case []interface{}:
+		if len(t) == 0 {
+			dst[prefix] = "[]"
+		}
+		for i, cv := range t {
+			flattenLogfmtNormalized(dst, prefix+"."+strconv.Itoa(i), cv)
+		}
+	case nil:
+		dst[prefix] = "null"
+	case string:
+		dst[prefix] = t
+	default:
+		dst[prefix] = fmt.Sprint(t)
+	}
+}
+
+// writeLogfmtField writes built-in field pair and mark the key as written
+func writeLogfmtField(buf *bytes.Buffer, builtIn map[string]bool, k, v string) {
+	if k == "" {
+		return
+	}
+	builtIn[k] = true
+	writeLogfmtPair(buf, k, v)
+}
+
+// writeLogfmtPair writes a key=value pair to buffer. Key will be sanitized and value will be quoted if required
+func writeLogfmtPair(buf *bytes.Buffer, k, v string) {
+	if k == "" {
+		return
+	}
+
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+
+	buf.WriteString(sanitizeLogfmtKey(k))
+	buf.WriteByte('=')
+
+	if needsLogfmtQuote(v) {
+		buf.WriteString(strconv.Quote(v))
+	} else {
+		buf.WriteString(v)
+	}
+}
+
+func sanitizeLogfmtKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, k)
+}
+
+func needsLogfmtQuote(v string) bool {
+	if v == "" {
+		return true
+	}
+
+	for _, r := range v {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
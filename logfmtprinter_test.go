@@ -0,0 +1,62 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/nbs-go/nlogger/v2"
+	logContext "github.com/nbs-go/nlogger/v2/context"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"testing"
+)
+
+func TestLogfmtPrinter(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewLogfmtPrinter(buf, nlogger.WithTimestampKey(""))
+	testLogger := nlogger.NewStdLogger(p, logOption.Level(level.Debug), logOption.WithNamespace("logfmt"))
+
+	meta := map[string]interface{}{
+		"string":  "string",
+		"integer": 0,
+		"boolean": true,
+		"array":   []int{1, 2, 3, 4, 5},
+		"struct": struct {
+			Text    string
+			Boolean bool
+		}{
+			Text:    "text",
+			Boolean: false,
+		},
+	}
+
+	ctx := logContext.SetRequestId(context.Background(), "req-1")
+	testLogger.Error("Testing logfmt with options: %s",
+		logOption.Error(errors.New(`a "quoted" error`)),
+		logOption.Context(ctx),
+		logOption.Metadata(meta),
+		logOption.AddMetadata("level", "must be ignored"),
+		logOption.AddMetadata("nested", map[string]interface{}{"b": 2, "a": map[string]string{"c": "d e"}}),
+		logOption.Format("arg1"),
+	)
+
+	expected := `level=Error namespace=logfmt msg="Testing logfmt with options: arg1" requestId=req-1 ` +
+		`error="a \"quoted\" error" array.0=1 array.1=2 array.2=3 array.3=4 array.4=5 boolean=true integer=0 ` +
+		`nested.a.c="d e" nested.b=2 string=string struct.Boolean=false struct.Text=text` + "\n"
+
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected logfmt output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
+
+func TestLogfmtPrinter_EmptyValue(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewLogfmtPrinter(buf, nlogger.WithTimestampKey(""), nlogger.WithLevelKey(""))
+	testLogger := nlogger.NewStdLogger(p, logOption.Level(level.Info))
+	testLogger.Info("", logOption.AddMetadata("invalid key", nil))
+
+	expected := `msg="" invalid_key=null` + "\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected logfmt output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
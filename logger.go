@@ -2,9 +2,7 @@ package nlogger
 
 import (
 	"fmt"
-	"github.com/nbs-go/nlogger/v2/level"
 	"github.com/nbs-go/nlogger/v2/option"
-	stdLog "log"
 	"os"
 	"sync"
 )
@@ -48,40 +46,87 @@ type Logger interface {
 	Tracef(format string, args ...interface{})
 
 	// NewChild must create a child logger and inherit level, writer and other flags
-	// only option such as namespace could be overridden
+	// only option such as namespace could be overridden. Metadata and fields set in args must be written
+	// in every entry of the child logger
 	NewChild(args ...logOption.SetterFunc) Logger
 }
 
-// log is a singleton logger instance
-var log Logger
-var logMutex sync.RWMutex
+// registry holds the registered logger instance. All fields are guarded by mutex
+type registry struct {
+	mu     sync.RWMutex
+	logger Logger
+	// generation is incremented every time logger instance is changed
+	generation uint64
+	// startup is the active startup buffer
+	startup *startupBuffer
+}
+
+// log is a singleton registry of logger instance
+var log registry
+
+// root is a proxy that resolves registered logger
+var root = &proxyLogger{}
 
-// Get retrieve logger instance and will fallback to StdLogger if no logger registered
+// Get retrieve a Logger that resolves the registered logger on each call, so it is safe to be called before
+// a logger implementation is registered. If no logger registered, it will fallback to StdLogger
 func Get() Logger {
-	// If log is nil, initiate standard logger
-	if log == nil {
-		// Get logger from env
-		logLevelStr, _ := os.LookupEnv(EnvLogLevel)
-		logLevel := level.Parse(logLevelStr)
-
-		// Get logger prefix
-		namespace, _ := os.LookupEnv(EnvLogNamespace)
-
-		// Init standard logger
-		p := NewStdLogPrinter(os.Stdout, stdLog.LstdFlags)
-		l := NewStdLogger(p, logOption.Level(logLevel), logOption.WithNamespace(namespace))
-
-		// Register logger
-		Register(l)
-		log.Trace("No logger found. StdLogger initiated")
-	}
-	return log
+	return root
 }
 
+// NewChild creates a child of the Logger returned by Get. The child is created from registered logger
+// on each call, and cached until another logger is registered
 func NewChild(args ...logOption.SetterFunc) Logger {
-	// Get parent logger
-	logger := Get()
-	return logger.NewChild(args...)
+	return root.NewChild(args...)
+}
+
+// current returns registered logger and its generation. If no logger registered, then it returns startup buffer
+// if enabled, or initiate StdLogger
+func current() (Logger, uint64) {
+	log.mu.RLock()
+	l, generation, b := log.logger, log.generation, log.startup
+	log.mu.RUnlock()
+
+	if l != nil {
+		return l, generation
+	}
+
+	// If startup buffer is enabled, then write entries to buffer
+	if b != nil {
+		return b.root, generation
+	}
+
+	// If log is nil, initiate fallback logger. Entries of concurrent callers and driver factory are buffered
+	// while fallback logger is constructed, then replayed to the fallback logger
+	log.mu.Lock()
+	if log.logger != nil || log.startup != nil {
+		log.mu.Unlock()
+		return current()
+	}
+	b = newStartupBuffer(fallbackBufferSize)
+	log.startup = b
+	log.generation++
+	log.mu.Unlock()
+
+	// Logger is constructed without holding the lock, since driver factory may call this package
+	l = newFallbackLogger(os.Stdout)
+
+	log.mu.Lock()
+
+	// If logger is registered or buffer is flushed while constructing fallback logger, then use the current one
+	if log.startup != b {
+		log.mu.Unlock()
+		return current()
+	}
+
+	log.logger = l
+	log.startup = nil
+	log.generation++
+	generation = log.generation
+	log.mu.Unlock()
+
+	l.Trace("No logger found. Fallback logger initiated")
+	b.replay(l)
+	return l, generation
 }
 
 // Register a logger implementation instance
@@ -91,16 +136,73 @@ func Register(l Logger) {
 		panic(fmt.Errorf("%s: logger to be registered is nil", pkgNamespace))
 	}
 
-	// Set logger
-	logMutex.Lock()
-	defer logMutex.Unlock()
-	log = l
+	swap(l)
+}
+
+// Replace temporarily replace registered logger. It is intended for tests only. It returns a function to restore
+// the previous logger. If logger is registered or cleared after Replace, then restore does nothing.
+// Startup buffer is not replayed to the replacement logger, it is kept for the logger registered later
+func Replace(l Logger) (restore func()) {
+	// If logger is nil, return error
+	if l == nil {
+		panic(fmt.Errorf("%s: logger to be replaced is nil", pkgNamespace))
+	}
+
+	// Resolve proxy, so the replacement logger will not resolve itself
+	if p, ok := l.(*proxyLogger); ok {
+		l = p.resolve()
+	}
+
+	log.mu.Lock()
+	prev := log.logger
+	log.logger = l
+	log.generation++
+	generation := log.generation
+	log.mu.Unlock()
+
+	return func() {
+		log.mu.Lock()
+		defer log.mu.Unlock()
+
+		// Keep logger that is changed after Replace
+		if log.generation != generation {
+			return
+		}
+		log.logger = prev
+		log.generation++
+	}
 }
 
 // Clear logger implementation instance
 func Clear() {
 	// Set logger
-	logMutex.Lock()
-	defer logMutex.Unlock()
-	log = nil
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	log.logger = nil
+	log.generation++
+}
+
+// swap set logger instance and returns the previous instance. Entries in startup buffer are replayed to
+// the new logger
+func swap(l Logger) Logger {
+	// Resolve proxy, so the registered logger will not resolve itself
+	if p, ok := l.(*proxyLogger); ok {
+		l = p.resolve()
+	}
+
+	// Set logger
+	log.mu.Lock()
+	prev := log.logger
+	log.logger = l
+	log.generation++
+	b := log.startup
+	log.startup = nil
+	log.mu.Unlock()
+
+	// Replay buffered entries to registered logger
+	if b != nil {
+		b.replay(l)
+	}
+
+	return prev
 }
@@ -0,0 +1,119 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGet_Concurrently(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	var wg sync.WaitGroup
+	loggers := make([]nlogger.Logger, 20)
+	for i := range loggers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			loggers[i] = nlogger.Unwrap(nlogger.Get())
+		}(i)
+	}
+	wg.Wait()
+
+	// All goroutines must get the same fallback logger
+	for _, l := range loggers {
+		if l != loggers[0] {
+			t.Errorf("multiple fallback loggers are initiated")
+		}
+	}
+}
+
+func TestRegister_Concurrently(t *testing.T) {
+	defer nlogger.Clear()
+
+	l := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0), logOption.Level(level.Trace))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			nlogger.Register(l)
+		}()
+		go func() {
+			defer wg.Done()
+			nlogger.Clear()
+		}()
+		go func() {
+			defer wg.Done()
+			nlogger.NewChild(logOption.WithNamespace("concurrent")).Trace("concurrent log")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestReplace(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	original := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Info))
+	nlogger.Register(original)
+	defer nlogger.Clear()
+
+	replaced := bytes.NewBuffer(nil)
+	restore := nlogger.Replace(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(replaced, 0), logOption.Level(level.Info)))
+	nlogger.Get().Info("replaced")
+	restore()
+	nlogger.Get().Info("restored")
+
+	if expected := " [INFO] replaced\n"; replaced.String() != expected {
+		t.Errorf("unexpected replaced output = %s", replaced.String())
+	}
+
+	if expected := " [INFO] restored\n"; buf.String() != expected {
+		t.Errorf("unexpected original output = %s", buf.String())
+	}
+}
+
+func TestReplace_RegisterBeforeRestore(t *testing.T) {
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0)))
+	defer nlogger.Clear()
+
+	restore := nlogger.Replace(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0)))
+
+	// Logger registered after Replace must not be overwritten by restore
+	registered := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0))
+	nlogger.Register(registered)
+	restore()
+
+	if l := nlogger.Unwrap(nlogger.Get()); l != registered {
+		t.Errorf("registered logger is overwritten by restore")
+	}
+}
+
+func TestReplace_StartupBuffer(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	nlogger.EnableStartupBuffer(10, time.Minute)
+	nlogger.Get().Error("buffered")
+
+	replaced := bytes.NewBuffer(nil)
+	restore := nlogger.Replace(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(replaced, 0)))
+	nlogger.Get().Error("replaced")
+	restore()
+
+	if expected := "[ERROR] replaced\n"; replaced.String() != expected {
+		t.Errorf("unexpected replaced output = %q", replaced.String())
+	}
+
+	// Buffered entries are replayed to the logger registered after restore
+	buf := bytes.NewBuffer(nil)
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0)))
+	if expected := "[ERROR] buffered\n"; buf.String() != expected {
+		t.Errorf("unexpected registered output = %q", buf.String())
+	}
+}
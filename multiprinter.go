@@ -0,0 +1,98 @@
+package nlogger
+
+import (
+	"context"
+	"fmt"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"os"
+	"strings"
+)
+
+// PrinterOutput is an output of multi printer
+type PrinterOutput struct {
+	// Printer prints entries of this output
+	Printer Printer
+	// Level is the least severe level to be printed, e.g. level.Warn prints WARN, ERROR and FATAL entries
+	Level level.LogLevel
+	// Namespaces filters entries by namespace. A namespace matches itself and its dotted children,
+	// e.g. "db" matches "db" and "db.pool". If empty, entries of all namespaces are printed
+	Namespaces []string
+}
+
+// Output creates PrinterOutput
+func Output(p Printer, lv level.LogLevel, namespaces ...string) PrinterOutput {
+	return PrinterOutput{Printer: p, Level: lv, Namespaces: namespaces}
+}
+
+// matches returns true if entry should be printed to output
+func (o *PrinterOutput) matches(namespace string, lv level.LogLevel) bool {
+	if lv > o.Level {
+		return false
+	}
+
+	if len(o.Namespaces) == 0 {
+		return true
+	}
+
+	for _, ns := range o.Namespaces {
+		if namespace == ns || strings.HasPrefix(namespace, ns+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// NewMultiPrinter creates a Printer that dispatches each entry to outputs which level and namespace match.
+// Panic in an output is recovered and reported to stderr, so it does not affect other outputs
+func NewMultiPrinter(outputs ...PrinterOutput) *multiPrinter {
+	return &multiPrinter{outputs: outputs}
+}
+
+type multiPrinter struct {
+	outputs []PrinterOutput
+}
+
+func (m *multiPrinter) Print(namespace string, outLevel level.LogLevel, msg string, options *logOption.Options) {
+	for i := range m.outputs {
+		o := &m.outputs[i]
+		if !o.matches(namespace, outLevel) {
+			continue
+		}
+		// Each output gets its own copy, so changes in an output are not visible to the others
+		m.print(i, o.Printer, namespace, outLevel, msg, copyOptions(options))
+	}
+}
+
+// print prints entry to output and recover panic
+func (m *multiPrinter) print(i int, p Printer, namespace string, outLevel level.LogLevel, msg string,
+	options *logOption.Options) {
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s: output %d panicked while printing entry. Error = %v\n", pkgNamespace, i, r)
+		}
+	}()
+	p.Print(namespace, outLevel, msg, options)
+}
+
+// Sync flushes all outputs. It returns the first error
+func (m *multiPrinter) Sync() error {
+	var err error
+	for _, o := range m.outputs {
+		if syncErr := syncOf(o.Printer); syncErr != nil && err == nil {
+			err = syncErr
+		}
+	}
+	return err
+}
+
+// Close closes all outputs. It returns the first error
+func (m *multiPrinter) Close(ctx context.Context) error {
+	var err error
+	for _, o := range m.outputs {
+		if closeErr := closeOf(ctx, o.Printer); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
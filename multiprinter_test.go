@@ -0,0 +1,134 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"strings"
+	"testing"
+	"time"
+)
+
+// panicPrinter panics on every entry
+type panicPrinter struct{}
+
+func (panicPrinter) Print(string, level.LogLevel, string, *logOption.Options) {
+	panic("broken output")
+}
+
+func TestMultiPrinter_Level(t *testing.T) {
+	text := bytes.NewBuffer(nil)
+	file := bytes.NewBuffer(nil)
+
+	p := nlogger.NewMultiPrinter(
+		nlogger.Output(nlogger.NewStdLogPrinter(text, 0), level.Info),
+		nlogger.Output(nlogger.NewJSONPrinter(file), level.Error),
+	)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Debug))
+
+	log.Debug("debug")
+	log.Info("info")
+	log.Error("error")
+
+	if expected := " [INFO] info\n[ERROR] error\n"; text.String() != expected {
+		t.Errorf("unexpected text output = %q", text.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("unexpected json output = %q", file.String())
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if entry["message"] != "error" {
+		t.Errorf("unexpected json entry = %v", entry)
+	}
+}
+
+func TestMultiPrinter_Namespace(t *testing.T) {
+	all := bytes.NewBuffer(nil)
+	db := bytes.NewBuffer(nil)
+
+	p := nlogger.NewMultiPrinter(
+		nlogger.Output(nlogger.NewLogfmtPrinter(all), level.Trace),
+		nlogger.Output(nlogger.NewLogfmtPrinter(db), level.Trace, "db"),
+	)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Info))
+
+	log.Info("root")
+	log.NewChild(logOption.WithNamespace("db")).Info("db")
+	log.NewChild(logOption.WithNamespace("db.pool")).Info("pool")
+	log.NewChild(logOption.WithNamespace("dbx")).Info("dbx")
+
+	if n := strings.Count(all.String(), "\n"); n != 4 {
+		t.Errorf("unexpected entries count = %d", n)
+	}
+
+	out := db.String()
+	if strings.Count(out, "\n") != 2 || !strings.Contains(out, "msg=db") || !strings.Contains(out, "msg=pool") {
+		t.Errorf("unexpected filtered output = %q", out)
+	}
+}
+
+func TestMultiPrinter_Panic(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	p := nlogger.NewMultiPrinter(
+		nlogger.Output(panicPrinter{}, level.Trace),
+		nlogger.Output(nlogger.NewStdLogPrinter(buf, 0), level.Trace),
+	)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Info))
+
+	log.Info("survived")
+
+	if expected := " [INFO] survived\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+}
+
+func TestMultiPrinter_Lifecycle(t *testing.T) {
+	p1 := newLifecyclePrinter()
+	p2 := newLifecyclePrinter()
+	p := nlogger.NewMultiPrinter(nlogger.Output(p1, level.Trace), nlogger.Output(p2, level.Error))
+
+	if err := p.Sync(); err != nil {
+		t.Errorf("unexpected error = %s", err)
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Errorf("unexpected error = %s", err)
+	}
+
+	if p1.syncs != 1 || p2.syncs != 1 || p1.closes != 1 || p2.closes != 1 {
+		t.Errorf("expected all outputs are synced and closed")
+	}
+}
+
+// mutatingPrinter changes options that are passed to it
+type mutatingPrinter struct{}
+
+func (mutatingPrinter) Print(_ string, _ level.LogLevel, _ string, options *logOption.Options) {
+	options.Values[logOption.TimestampKey] = time.Time{}
+	options.Metadata["key"] = "mutated"
+}
+
+func TestMultiPrinter_CopyOptions(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	p := nlogger.NewMultiPrinter(
+		nlogger.Output(mutatingPrinter{}, level.Trace),
+		nlogger.Output(nlogger.NewLogfmtPrinter(buf), level.Trace),
+	)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Info))
+
+	log.NewChild(logOption.WithNamespace("app")).Info("shared", logOption.AddMetadata("key", "value"))
+
+	out := buf.String()
+	if strings.Contains(out, "0001-01-01") || !strings.Contains(out, "key=value") {
+		t.Errorf("unexpected output = %q", out)
+	}
+}
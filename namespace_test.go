@@ -0,0 +1,109 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"reflect"
+	"testing"
+)
+
+// pathPrinter records namespace and namespace path of entries
+type pathPrinter struct {
+	namespaces []string
+	paths      [][]string
+}
+
+func (p *pathPrinter) Print(namespace string, _ level.LogLevel, _ string, options *logOption.Options) {
+	p.namespaces = append(p.namespaces, namespace)
+	path, _ := logOption.GetStrings(options, logOption.NamespacePathKey)
+	p.paths = append(p.paths, path)
+}
+
+func TestNamespace_Default(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	api := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Info),
+		logOption.WithNamespace("api"))
+
+	api.NewChild(logOption.WithNamespace("db")).Info("replaced")
+
+	if expected := " [INFO] (db) replaced\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+}
+
+func TestNamespace_Hierarchical(t *testing.T) {
+	p := &pathPrinter{}
+	root := nlogger.NewStdLogger(p, logOption.Level(level.Info), logOption.HierarchicalNamespace())
+
+	api := root.NewChild(logOption.WithNamespace("api"))
+	db := api.NewChild(logOption.WithNamespace("db"))
+	query := db.NewChild(logOption.WithNamespace("query"))
+
+	root.Info("root")
+	api.Info("api")
+	query.Info("query")
+	query.NewChild(logOption.AddMetadata("k", "v")).Info("inherit")
+
+	expectedNamespaces := []string{"", "api", "api.db.query", "api.db.query"}
+	if !reflect.DeepEqual(p.namespaces, expectedNamespaces) {
+		t.Errorf("unexpected namespaces = %v", p.namespaces)
+	}
+
+	expectedPaths := [][]string{nil, {"api"}, {"api", "db", "query"}, {"api", "db", "query"}}
+	if !reflect.DeepEqual(p.paths, expectedPaths) {
+		t.Errorf("unexpected namespace paths = %v", p.paths)
+	}
+}
+
+func TestNamespace_HierarchicalChildOnly(t *testing.T) {
+	p := &pathPrinter{}
+	api := nlogger.NewStdLogger(p, logOption.Level(level.Info), logOption.WithNamespace("api"))
+
+	// Enable hierarchical mode on child, then it is inherited by descendants
+	db := api.NewChild(logOption.WithNamespace("db"), logOption.HierarchicalNamespace())
+	db.NewChild(logOption.WithNamespace("query")).Info("query")
+
+	if expected := []string{"api.db.query"}; !reflect.DeepEqual(p.namespaces, expected) {
+		t.Errorf("unexpected namespaces = %v", p.namespaces)
+	}
+}
+
+func TestNamespace_HierarchicalLevel(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	root := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Info),
+		logOption.HierarchicalNamespace())
+
+	query := root.NewChild(logOption.WithNamespace("api")).
+		NewChild(logOption.WithNamespace("db")).
+		NewChild(logOption.WithNamespace("query"))
+
+	query.Debug("hidden")
+	root.SetNamespaceLevel("api.db", level.Debug)
+	query.Debug("shown")
+
+	if expected := "[DEBUG] (api.db.query) shown\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+}
+
+func TestNamespace_HierarchicalFilter(t *testing.T) {
+	all := &pathPrinter{}
+	db := &pathPrinter{}
+	root := nlogger.NewStdLogger(nlogger.NewMultiPrinter(
+		nlogger.Output(all, level.Trace),
+		nlogger.Output(db, level.Trace, "api.db"),
+	), logOption.Level(level.Info), logOption.HierarchicalNamespace())
+
+	api := root.NewChild(logOption.WithNamespace("api"))
+	api.Info("api")
+	api.NewChild(logOption.WithNamespace("db")).NewChild(logOption.WithNamespace("query")).Info("query")
+
+	if len(all.namespaces) != 2 {
+		t.Errorf("unexpected entries = %v", all.namespaces)
+	}
+	if expected := []string{"api.db.query"}; !reflect.DeepEqual(db.namespaces, expected) {
+		t.Errorf("unexpected filtered entries = %v", db.namespaces)
+	}
+}
@@ -0,0 +1,25 @@
+package logOption
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Caller contains information where a log call is originated
+type Caller struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function"`
+}
+
+// String returns caller in short format, e.g. "package/file.go:10"
+func (c Caller) String() string {
+	file := c.File
+	// Trim file path to package directory and file name
+	if i := strings.LastIndex(file, "/"); i > 0 {
+		if j := strings.LastIndex(file[:i], "/"); j >= 0 {
+			file = file[j+1:]
+		}
+	}
+	return file + ":" + strconv.Itoa(c.Line)
+}
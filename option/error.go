@@ -0,0 +1,77 @@
+package logOption
+
+import "fmt"
+
+// maxErrorChain limits number of errors to be walked in an error chain
+const maxErrorChain = 100
+
+// ErrorCause describes an error in error chain
+type ErrorCause struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// ErrorFielder is an error that carries structured fields to be logged
+type ErrorFielder interface {
+	LogFields() map[string]interface{}
+}
+
+// ErrorChain returns err and its wrapped errors in depth-first order. Wrapped errors are resolved with
+// Unwrap() error and Unwrap() []error that are used by errors.Unwrap and errors.Join
+func ErrorChain(err error) []ErrorCause {
+	var chain []ErrorCause
+	walkError(err, func(e error) {
+		chain = append(chain, ErrorCause{
+			Type:    fmt.Sprintf("%T", e),
+			Message: e.Error(),
+		})
+	})
+	return chain
+}
+
+// ErrorFields returns fields carried by errors in error chain that implements ErrorFielder.
+// If there are duplicate keys, then fields of outer error take precedence
+func ErrorFields(err error) map[string]interface{} {
+	var fielders []ErrorFielder
+	walkError(err, func(e error) {
+		if f, ok := e.(ErrorFielder); ok {
+			fielders = append(fielders, f)
+		}
+	})
+
+	if len(fielders) == 0 {
+		return nil
+	}
+
+	// Merge from the innermost error
+	fields := make(map[string]interface{})
+	for i := len(fielders) - 1; i >= 0; i-- {
+		for k, v := range fielders[i].LogFields() {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// walkError calls fn for err and its wrapped errors in depth-first order
+func walkError(err error, fn func(error)) {
+	queue := []error{err}
+	for n := 0; len(queue) > 0 && n < maxErrorChain; n++ {
+		// Pop error
+		e := queue[0]
+		queue = queue[1:]
+		if e == nil {
+			continue
+		}
+
+		fn(e)
+
+		// Push wrapped errors to the front of queue
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			queue = append([]error{u.Unwrap()}, queue...)
+		case interface{ Unwrap() []error }:
+			queue = append(append([]error{}, u.Unwrap()...), queue...)
+		}
+	}
+}
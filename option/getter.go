@@ -1,6 +1,9 @@
 package logOption
 
-import "time"
+import (
+	"github.com/nbs-go/nlogger/v2/level"
+	"time"
+)
 
 // GetString is helper to retrieve string value in Values by key
 // Value type must be exact, as it use casting instead of converting to target value
@@ -36,6 +39,39 @@ func GetInt(o *Options, k string) (int, bool) {
 	return i, ok
 }
 
+// GetBool is helper to retrieve bool value in Values by key
+// Value type must be exact, as it use casting instead of converting to target value
+func GetBool(o *Options, k string) (bool, bool) {
+	v, ok := o.Values[k]
+	if !ok {
+		return false, false
+	}
+	b, ok := v.(bool)
+	return b, ok
+}
+
+// GetStrings is helper to retrieve string slice value in Values by key
+// Value type must be exact, as it use casting instead of converting to target value
+func GetStrings(o *Options, k string) ([]string, bool) {
+	v, ok := o.Values[k]
+	if !ok {
+		return nil, false
+	}
+	s, ok := v.([]string)
+	return s, ok
+}
+
+// GetCaller is helper to retrieve captured Caller value in Values by key
+// Value type must be exact, as it use casting instead of converting to target value
+func GetCaller(o *Options, k string) (Caller, bool) {
+	v, ok := o.Values[k]
+	if !ok {
+		return Caller{}, false
+	}
+	c, ok := v.(Caller)
+	return c, ok
+}
+
 // GetTime is helper to retrieve time.Time value in Values by key
 // Value type must be exact, as it use casting instead of converting to target value
 func GetTime(o *Options, k string) (time.Time, bool) {
@@ -47,6 +83,17 @@ func GetTime(o *Options, k string) (time.Time, bool) {
 	return t, ok
 }
 
+// GetLevelVar is helper to retrieve *level.Var value in Values by key
+// Value type must be exact, as it use casting instead of converting to target value
+func GetLevelVar(o *Options, k string) *level.Var {
+	v, ok := o.Values[k]
+	if !ok {
+		return nil
+	}
+	lv, _ := v.(*level.Var)
+	return lv
+}
+
 // GetError is helper to retrieve error value in Values by key
 // Value type must be exact, as it use casting instead of converting to target value
 func GetError(o *Options, k string) error {
@@ -54,5 +101,6 @@ func GetError(o *Options, k string) error {
 	if !ok {
 		return nil
 	}
-	return v.(error)
+	err, _ := v.(error)
+	return err
 }
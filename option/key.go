@@ -2,6 +2,26 @@ package logOption
 
 // Option keys constants
 const (
-	ErrorKey     = "error"
-	NamespaceKey = "namespace"
+	ErrorKey           = "error"
+	NamespaceKey       = "namespace"
+	LevelVarKey        = "levelVar"
+	NamespaceLevelsKey = "namespaceLevels"
+	CallerKey          = "caller"
+	CallerEnabledKey   = "callerEnabled"
+	CallerSkipKey      = "callerSkip"
+	StackKey           = "stack"
+	StackEnabledKey    = "stackEnabled"
+	StackLevelKey      = "stackLevel"
+	FatalActionKey     = "fatalAction"
+	FatalExitCodeKey   = "fatalExitCode"
+	FatalHookKey       = "fatalHook"
+	TimestampKey       = "timestamp"
+)
+
+// Namespace option keys
+const (
+	// NamespacePathKey holds segments of dotted namespace, e.g. ["api", "db", "query"] for "api.db.query"
+	NamespacePathKey = "namespacePath"
+	// HierarchicalNamespaceKey enables joining child namespace with parent namespace
+	HierarchicalNamespaceKey = "hierarchicalNamespace"
 )
@@ -0,0 +1,101 @@
+package logOption
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// LazyValue is a value that is computed only when entry is printed. It can be used as metadata value or
+// as format argument, e.g.
+//
+//	log.Debugf("request body: %s", logOption.Lazy(func() interface{} { return dump(req) }))
+type LazyValue func() interface{}
+
+// Lazy creates a LazyValue from fn
+func Lazy(fn func() interface{}) LazyValue {
+	return fn
+}
+
+// Format implements fmt.Formatter, so value is only computed when message is formatted
+func (v LazyValue) Format(f fmt.State, verb rune) {
+	_, _ = fmt.Fprintf(f, formatDirective(f, verb), v.Resolve())
+}
+
+// Resolve returns computed value. If v is nil, it returns nil
+func (v LazyValue) Resolve() interface{} {
+	if v == nil {
+		return nil
+	}
+	return v()
+}
+
+// AddLazyMetadata set metadata that its value is computed only when entry is printed
+func AddLazyMetadata(key string, fn func() interface{}) SetterFunc {
+	return AddMetadata(key, LazyValue(fn))
+}
+
+// ResolveMetadata returns metadata with computed lazy values. If metadata contains lazy value, then
+// a new map is returned, so the original map is not changed
+func ResolveMetadata(m map[string]interface{}) map[string]interface{} {
+	var resolved map[string]interface{}
+	for k, v := range m {
+		lv, ok := v.(LazyValue)
+		if !ok {
+			continue
+		}
+
+		if resolved == nil {
+			resolved = make(map[string]interface{}, len(m))
+			for mk, mv := range m {
+				resolved[mk] = mv
+			}
+		}
+		resolved[k] = lv.Resolve()
+	}
+
+	if resolved == nil {
+		return m
+	}
+	return resolved
+}
+
+// ResolveArgs returns format arguments with computed lazy values. If arguments contain lazy value, then
+// a new slice is returned, so the original slice is not changed
+func ResolveArgs(args []interface{}) []interface{} {
+	var resolved []interface{}
+	for i, v := range args {
+		lv, ok := v.(LazyValue)
+		if !ok {
+			continue
+		}
+
+		if resolved == nil {
+			resolved = make([]interface{}, len(args))
+			copy(resolved, args)
+		}
+		resolved[i] = lv.Resolve()
+	}
+
+	if resolved == nil {
+		return args
+	}
+	return resolved
+}
+
+// formatDirective returns format directive of state, e.g. "%-10.2f"
+func formatDirective(f fmt.State, verb rune) string {
+	b := []byte{'%'}
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b = append(b, byte(flag))
+		}
+	}
+	if w, ok := f.Width(); ok {
+		b = strconv.AppendInt(b, int64(w), 10)
+	}
+	if p, ok := f.Precision(); ok {
+		b = append(b, '.')
+		b = strconv.AppendInt(b, int64(p), 10)
+	}
+	return string(append(b, string(verb)...))
+}
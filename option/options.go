@@ -2,19 +2,35 @@ package logOption
 
 import (
 	"context"
+	logField "github.com/nbs-go/nlogger/v2/field"
 	"github.com/nbs-go/nlogger/v2/level"
 )
 
 type Options struct {
 	Values   map[string]interface{}
 	Metadata map[string]interface{}
-	FmtArgs  []interface{}
-	Context  context.Context
-	Level    level.LogLevel
+	// Fields are typed fields of entry in the order they are added
+	Fields  []logField.Field
+	FmtArgs []interface{}
+	Context context.Context
+	Level   level.LogLevel
 }
 
 type SetterFunc = func(*Options)
 
+// FatalAction defines what logger do after an entry in FATAL level is printed
+type FatalAction = int8
+
+// FatalAction constants
+const (
+	// FatalNone returns to caller after printing entry
+	FatalNone FatalAction = iota
+	// FatalExit terminates process with exit code
+	FatalExit
+	// FatalPanic panics with the message of entry
+	FatalPanic
+)
+
 // NewOptions construct options
 func NewOptions() *Options {
 	return &Options{
@@ -2,7 +2,9 @@ package logOption
 
 import (
 	"context"
+	logField "github.com/nbs-go/nlogger/v2/field"
 	"github.com/nbs-go/nlogger/v2/level"
+	"time"
 )
 
 func AddMetadata(key string, val interface{}) SetterFunc {
@@ -20,6 +22,13 @@ func Metadata(m map[string]interface{}) SetterFunc {
 	}
 }
 
+// Fields add typed fields to entry. Fields with the same key as metadata take precedence
+func Fields(fields ...logField.Field) SetterFunc {
+	return func(o *Options) {
+		o.Fields = append(o.Fields, fields...)
+	}
+}
+
 func Format(args ...interface{}) SetterFunc {
 	return func(o *Options) {
 		o.FmtArgs = args
@@ -49,3 +58,87 @@ func Level(lv level.LogLevel) SetterFunc {
 		o.Level = lv
 	}
 }
+
+// NamespaceLevels set level overrides for each namespace. Override is matched by namespace hierarchy
+// that separated by dot, e.g. override "http" also applies to "http.client" unless it has its own override
+func NamespaceLevels(m map[string]level.LogLevel) SetterFunc {
+	return func(o *Options) {
+		o.Values[NamespaceLevelsKey] = m
+	}
+}
+
+// HierarchicalNamespace enable hierarchical namespace. If set on logger, namespace of child logger is joined with
+// parent namespace by dot, e.g. child "db" of "api" has namespace "api.db". Child loggers inherit this option
+func HierarchicalNamespace() SetterFunc {
+	return func(o *Options) {
+		o.Values[HierarchicalNamespaceKey] = true
+	}
+}
+
+// WithCaller enable caller capture. If set on logger, caller will be captured on every log call
+func WithCaller() SetterFunc {
+	return func(o *Options) {
+		o.Values[CallerEnabledKey] = true
+	}
+}
+
+// CallerSkip set additional number of stack frames to skip when capturing caller.
+// It is useful for wrappers of Logger, so the captured caller is the caller of the wrapper
+func CallerSkip(skip int) SetterFunc {
+	return func(o *Options) {
+		o.Values[CallerSkipKey] = skip
+	}
+}
+
+// WithStack enable stack trace capture. If the error option carries stack trace, then it will be used instead of
+// the stack trace at call site. If set on logger, stack trace will be captured on every log call
+func WithStack() SetterFunc {
+	return func(o *Options) {
+		o.Values[StackEnabledKey] = true
+	}
+}
+
+// StackLevel set logger to capture stack trace for entries at the given level and more severe levels,
+// e.g. StackLevel(level.Error) captures stack trace on Error and Fatal
+func StackLevel(lv level.LogLevel) SetterFunc {
+	return func(o *Options) {
+		o.Values[StackLevelKey] = lv
+	}
+}
+
+// OnFatal set action after an entry in FATAL level is printed. Default action is FatalNone.
+// If set in log call, it overrides logger action for the entry
+func OnFatal(action FatalAction) SetterFunc {
+	return func(o *Options) {
+		o.Values[FatalActionKey] = action
+	}
+}
+
+// FatalExitCode set exit code that is used by FatalExit action. Default exit code is 1
+func FatalExitCode(code int) SetterFunc {
+	return func(o *Options) {
+		o.Values[FatalExitCodeKey] = code
+	}
+}
+
+// FatalHook set function that is called after an entry in FATAL level is printed and before fatal action
+// is executed. If set in log call with nil, logger hook is not called for the entry
+func FatalHook(fn func(msg string)) SetterFunc {
+	return func(o *Options) {
+		o.Values[FatalHookKey] = fn
+	}
+}
+
+// Timestamp set time when the entry is created. If not set, logger will set it to current time
+func Timestamp(t time.Time) SetterFunc {
+	return func(o *Options) {
+		o.Values[TimestampKey] = t
+	}
+}
+
+// LevelVar set a shared level holder, so level can be changed at runtime. If set, Level option is ignored
+func LevelVar(v *level.Var) SetterFunc {
+	return func(o *Options) {
+		o.Values[LevelVarKey] = v
+	}
+}
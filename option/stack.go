@@ -0,0 +1,68 @@
+package logOption
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// FormatStack formats program counters as stack trace, each frame is written as function name
+// followed by file and line in the next line
+func FormatStack(pcs []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" || frame.File != "" {
+			_, _ = fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// ErrorStack returns stack trace carried by error or its wrapped errors. The innermost stack trace is returned,
+// since it is the closest to where the error is originated. Supported errors are errors that implements
+// StackTrace() method that returns program counters or a formattable value (e.g. github.com/pkg/errors),
+// and errors that implements Stack() []byte (e.g. github.com/go-errors/errors)
+func ErrorStack(err error) (string, bool) {
+	var stack string
+	var found bool
+	for err != nil {
+		if s, ok := extractStack(err); ok {
+			stack = s
+			found = true
+		}
+		err = errors.Unwrap(err)
+	}
+	return stack, found
+}
+
+func extractStack(err error) (string, bool) {
+	// Handle error that returns stack as bytes
+	if s, ok := err.(interface{ Stack() []byte }); ok {
+		return string(s.Stack()), true
+	}
+
+	// Handle error that has StackTrace method with any return type
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return "", false
+	}
+	v := m.Call(nil)[0]
+
+	// If stack trace is a list of program counters, then format as frames
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uintptr {
+		pcs := make([]uintptr, v.Len())
+		for i := range pcs {
+			pcs[i] = uintptr(v.Index(i).Uint())
+		}
+		return FormatStack(pcs), true
+	}
+
+	return strings.TrimPrefix(fmt.Sprintf("%+v", v.Interface()), "\n"), true
+}
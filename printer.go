@@ -1,11 +1,249 @@
 package nlogger
 
 import (
+	"fmt"
+	logField "github.com/nbs-go/nlogger/v2/field"
 	"github.com/nbs-go/nlogger/v2/level"
 	logOption "github.com/nbs-go/nlogger/v2/option"
+	"strconv"
+	"time"
 )
 
 // Printer defines interface that are able to print a log message
 type Printer interface {
 	Print(namespace string, outLevel level.LogLevel, msg string, options *logOption.Options)
 }
+
+// Time format constants that are not supported by time.Time Format layout
+const (
+	TimeFormatUnix      = "unix"
+	TimeFormatUnixMilli = "unixMilli"
+	TimeFormatUnixNano  = "unixNano"
+)
+
+// Default field keys used by structured printers
+const (
+	DefaultTimestampKey  = "timestamp"
+	DefaultLevelKey      = "level"
+	DefaultNamespaceKey  = "namespace"
+	DefaultMessageKey    = "message"
+	DefaultRequestIdKey  = "requestId"
+	DefaultErrorKey      = "error"
+	DefaultCallerKey     = "caller"
+	DefaultStackKey      = "stack"
+	DefaultErrorChainKey = "errorChain"
+)
+
+// PrinterOption is a function that override configuration of built-in Printer implementations
+type PrinterOption func(*printerOptions)
+
+type printerOptions struct {
+	timestampKey  string
+	levelKey      string
+	namespaceKey  string
+	messageKey    string
+	requestIdKey  string
+	errorKey      string
+	callerKey     string
+	stackKey      string
+	errorChainKey string
+	errorFields   bool
+	timeFormat    string
+	singleLine    bool
+}
+
+func newPrinterOptions(args []PrinterOption) *printerOptions {
+	o := printerOptions{
+		timestampKey:  DefaultTimestampKey,
+		levelKey:      DefaultLevelKey,
+		namespaceKey:  DefaultNamespaceKey,
+		messageKey:    DefaultMessageKey,
+		requestIdKey:  DefaultRequestIdKey,
+		errorKey:      DefaultErrorKey,
+		callerKey:     DefaultCallerKey,
+		stackKey:      DefaultStackKey,
+		errorChainKey: DefaultErrorChainKey,
+		timeFormat:    time.RFC3339,
+	}
+	for _, fn := range args {
+		fn(&o)
+	}
+	return &o
+}
+
+// formatTime returns timestamp value according to configured time format
+func (o *printerOptions) formatTime(t time.Time) interface{} {
+	switch o.timeFormat {
+	case TimeFormatUnix:
+		return t.Unix()
+	case TimeFormatUnixMilli:
+		return t.UnixNano() / int64(time.Millisecond)
+	case TimeFormatUnixNano:
+		return t.UnixNano()
+	default:
+		return t.Format(o.timeFormat)
+	}
+}
+
+// formatTimeString returns timestamp value as string according to configured time format
+func (o *printerOptions) formatTimeString(t time.Time) string {
+	switch v := o.formatTime(t).(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return v.(string)
+	}
+}
+
+// WithTimestampKey set key for timestamp field. If key is empty, timestamp will not be printed
+func WithTimestampKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.timestampKey = k
+	}
+}
+
+// WithLevelKey set key for level field. If key is empty, level will not be printed
+func WithLevelKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.levelKey = k
+	}
+}
+
+// WithNamespaceKey set key for namespace field. If key is empty, namespace will not be printed
+func WithNamespaceKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.namespaceKey = k
+	}
+}
+
+// WithMessageKey set key for message field. If key is empty, message will not be printed
+func WithMessageKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.messageKey = k
+	}
+}
+
+// WithRequestIdKey set key for request id field. If key is empty, request id will not be printed
+func WithRequestIdKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.requestIdKey = k
+	}
+}
+
+// WithErrorKey set key for error field. If key is empty, error will not be printed
+func WithErrorKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.errorKey = k
+	}
+}
+
+// WithCallerKey set key for caller field. If key is empty, caller will not be printed
+func WithCallerKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.callerKey = k
+	}
+}
+
+// WithStackKey set key for stack trace field. If key is empty, stack trace will not be printed
+func WithStackKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.stackKey = k
+	}
+}
+
+// WithErrorChainKey set key for error chain field that is printed if error wraps other errors.
+// If key is empty, error chain will not be printed
+func WithErrorChainKey(k string) PrinterOption {
+	return func(o *printerOptions) {
+		o.errorChainKey = k
+	}
+}
+
+// WithErrorFields set printer to merge fields carried by errors that implements logOption.ErrorFielder into
+// metadata. Metadata that set in log call take precedence
+func WithErrorFields() PrinterOption {
+	return func(o *printerOptions) {
+		o.errorFields = true
+	}
+}
+
+// WithTimeFormat set timestamp format. Value can be a time.Time layout or one of TimeFormatUnix,
+// TimeFormatUnixMilli and TimeFormatUnixNano
+func WithTimeFormat(layout string) PrinterOption {
+	return func(o *printerOptions) {
+		o.timeFormat = layout
+	}
+}
+
+// WithSingleLine set printer to write all details of log entry in a single line.
+// Only applicable to StdLogPrinter, as structured printers always write an entry in a single line
+func WithSingleLine() PrinterOption {
+	return func(o *printerOptions) {
+		o.singleLine = true
+	}
+}
+
+// metadata returns metadata of log entry. If error fields option is enabled, then error fields are merged
+func (o *printerOptions) metadata(options *logOption.Options) map[string]interface{} {
+	if !o.errorFields {
+		return options.Metadata
+	}
+
+	logErr := logOption.GetError(options, logOption.ErrorKey)
+	if logErr == nil {
+		return options.Metadata
+	}
+
+	fields := logOption.ErrorFields(logErr)
+	if len(fields) == 0 {
+		return options.Metadata
+	}
+
+	for k, v := range options.Metadata {
+		fields[k] = v
+	}
+	return fields
+}
+
+// copyOptions returns a copy of options with its own Values, Metadata, Fields and FmtArgs. If options is nil,
+// new options is returned
+func copyOptions(options *logOption.Options) *logOption.Options {
+	if options == nil {
+		return logOption.NewOptions()
+	}
+
+	c := *options
+	c.Values = make(map[string]interface{}, len(options.Values)+1)
+	for k, v := range options.Values {
+		c.Values[k] = v
+	}
+	if options.Metadata != nil {
+		c.Metadata = make(map[string]interface{}, len(options.Metadata))
+		for k, v := range options.Metadata {
+			c.Metadata[k] = v
+		}
+	}
+	if options.Fields != nil {
+		c.Fields = append([]logField.Field(nil), options.Fields...)
+	}
+	if options.FmtArgs != nil {
+		c.FmtArgs = append([]interface{}(nil), options.FmtArgs...)
+	}
+	return &c
+}
+
+// entryTime returns timestamp of log entry, or current time if not set
+func entryTime(options *logOption.Options) time.Time {
+	if t, ok := logOption.GetTime(options, logOption.TimestampKey); ok {
+		return t
+	}
+	return time.Now()
+}
+
+// formatMessage returns message that has been formatted with FmtArgs if available
+func formatMessage(msg string, options *logOption.Options) string {
+	if len(options.FmtArgs) > 0 {
+		return fmt.Sprintf(msg, options.FmtArgs...)
+	}
+	return msg
+}
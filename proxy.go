@@ -0,0 +1,220 @@
+package nlogger
+
+import (
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"sync/atomic"
+)
+
+// proxyLogger is a Logger that resolves the registered logger on each call, so loggers that are created before
+// a logger implementation is registered, e.g. in package variables or init(), will use the registered logger
+type proxyLogger struct {
+	// chain contains arguments of each NewChild call
+	chain [][]logOption.SetterFunc
+	cache atomic.Value
+}
+
+// proxyCache holds resolved child logger of a registered logger generation
+type proxyCache struct {
+	generation uint64
+	logger     Logger
+}
+
+// Unwrap returns the Logger that is currently resolved by proxy
+func (p *proxyLogger) Unwrap() Logger {
+	return p.resolve()
+}
+
+// resolve returns registered logger, or its child if proxy is created by NewChild
+func (p *proxyLogger) resolve() Logger {
+	l, generation := current()
+	if len(p.chain) == 0 {
+		return l
+	}
+
+	// Get cached child if registered logger is not changed
+	if c, ok := p.cache.Load().(*proxyCache); ok && c.generation == generation {
+		return c.logger
+	}
+
+	// Create child logger
+	for _, args := range p.chain {
+		l = l.NewChild(args...)
+	}
+	p.cache.Store(&proxyCache{generation: generation, logger: l})
+	return l
+}
+
+// The following methods call StdLogger.print directly if resolved logger is a StdLogger,
+// so the caller capture depth is the same as calling StdLogger methods
+
+func (p *proxyLogger) Fatal(msg string, args ...logOption.SetterFunc) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		sl.print(level.Fatal, msg, logOption.Evaluate(args))
+		return
+	}
+	l.Fatal(msg, args...)
+}
+
+func (p *proxyLogger) Fatalf(format string, args ...interface{}) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		sl.print(level.Fatal, format, logOption.NewFormatOptions(args...))
+		return
+	}
+	l.Fatalf(format, args...)
+}
+
+func (p *proxyLogger) Error(msg string, args ...logOption.SetterFunc) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Error) {
+			sl.print(level.Error, msg, logOption.Evaluate(args))
+		}
+		return
+	}
+	l.Error(msg, args...)
+}
+
+func (p *proxyLogger) Errorf(format string, args ...interface{}) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Error) {
+			sl.print(level.Error, format, logOption.NewFormatOptions(args...))
+		}
+		return
+	}
+	l.Errorf(format, args...)
+}
+
+func (p *proxyLogger) Warn(msg string, args ...logOption.SetterFunc) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Warn) {
+			sl.print(level.Warn, msg, logOption.Evaluate(args))
+		}
+		return
+	}
+	l.Warn(msg, args...)
+}
+
+func (p *proxyLogger) Warnf(format string, args ...interface{}) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Warn) {
+			sl.print(level.Warn, format, logOption.NewFormatOptions(args...))
+		}
+		return
+	}
+	l.Warnf(format, args...)
+}
+
+func (p *proxyLogger) Info(msg string, args ...logOption.SetterFunc) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Info) {
+			sl.print(level.Info, msg, logOption.Evaluate(args))
+		}
+		return
+	}
+	l.Info(msg, args...)
+}
+
+func (p *proxyLogger) Infof(format string, args ...interface{}) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Info) {
+			sl.print(level.Info, format, logOption.NewFormatOptions(args...))
+		}
+		return
+	}
+	l.Infof(format, args...)
+}
+
+func (p *proxyLogger) Debug(msg string, args ...logOption.SetterFunc) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Debug) {
+			sl.print(level.Debug, msg, logOption.Evaluate(args))
+		}
+		return
+	}
+	l.Debug(msg, args...)
+}
+
+func (p *proxyLogger) Debugf(format string, args ...interface{}) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Debug) {
+			sl.print(level.Debug, format, logOption.NewFormatOptions(args...))
+		}
+		return
+	}
+	l.Debugf(format, args...)
+}
+
+func (p *proxyLogger) Trace(msg string, args ...logOption.SetterFunc) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Trace) {
+			sl.print(level.Trace, msg, logOption.Evaluate(args))
+		}
+		return
+	}
+	l.Trace(msg, args...)
+}
+
+func (p *proxyLogger) Tracef(format string, args ...interface{}) {
+	l := p.resolve()
+	if sl, ok := l.(*StdLogger); ok {
+		if sl.level.Enabled(level.Trace) {
+			sl.print(level.Trace, format, logOption.NewFormatOptions(args...))
+		}
+		return
+	}
+	l.Tracef(format, args...)
+}
+
+func (p *proxyLogger) Log(lv level.LogLevel, msg string, fields ...logField.Field) {
+	l := p.resolve()
+	switch t := l.(type) {
+	case *StdLogger:
+		if lv == level.Fatal || t.level.Enabled(lv) {
+			t.print(lv, msg, fieldOptions(fields))
+		}
+	case FieldLogger:
+		t.Log(lv, msg, copyFields(fields)...)
+	default:
+		logFields(l, lv, msg, fields)
+	}
+}
+
+// Enabled returns true if entries in level lv will be printed by resolved logger
+func (p *proxyLogger) Enabled(lv level.LogLevel) bool {
+	return Enabled(p.resolve(), lv)
+}
+
+// With creates a child proxy that writes fields in every entry
+func (p *proxyLogger) With(fields ...logField.Field) Logger {
+	return p.NewChild(logOption.Fields(copyFields(fields)...))
+}
+
+func (p *proxyLogger) NewChild(args ...logOption.SetterFunc) Logger {
+	chain := make([][]logOption.SetterFunc, len(p.chain), len(p.chain)+1)
+	copy(chain, p.chain)
+	return &proxyLogger{chain: append(chain, args)}
+}
+
+// Unwrap returns the underlying Logger if l is a wrapper such as the Logger returned by Get and NewChild.
+// It is useful to check capabilities of registered logger implementation, e.g. level controller
+func Unwrap(l Logger) Logger {
+	for {
+		w, ok := l.(interface{ Unwrap() Logger })
+		if !ok {
+			return l
+		}
+		l = w.Unwrap()
+	}
+}
@@ -0,0 +1,71 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"runtime"
+	"testing"
+)
+
+func TestProxy_RegisterLater(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	// Create logger before registering implementation
+	child := nlogger.NewChild(logOption.WithNamespace("proxy")).NewChild(logOption.AddMetadata("key", "value"))
+
+	buf1 := bytes.NewBuffer(nil)
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf1, 0), logOption.Level(level.Info)))
+	child.Info("first")
+
+	if expected := " [INFO] (proxy) first\n  > Metadata: {\"key\":\"value\"}\n"; buf1.String() != expected {
+		t.Errorf("unexpected output = %s", buf1.String())
+	}
+
+	// Register another logger
+	buf2 := bytes.NewBuffer(nil)
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf2, 0), logOption.Level(level.Info)))
+	child.Infof("second %d", 2)
+
+	if expected := " [INFO] (proxy) second 2\n  > Metadata: {\"key\":\"value\"}\n"; buf2.String() != expected {
+		t.Errorf("unexpected output = %s", buf2.String())
+	}
+}
+
+func TestProxy_RegisterProxy(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	// Registering proxy must not cause infinite recursion
+	nlogger.Register(nlogger.Get())
+	nlogger.Get().Trace("registered proxy")
+
+	if _, ok := nlogger.Unwrap(nlogger.Get()).(*nlogger.StdLogger); !ok {
+		t.Errorf("unexpected registered logger type")
+	}
+}
+
+func TestProxy_Caller(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	buf := bytes.NewBuffer(nil)
+	nlogger.Register(nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf), logOption.WithCaller()))
+
+	_, _, line, _ := runtime.Caller(0)
+	nlogger.Get().Errorf("log from proxy")
+
+	var entry struct {
+		Caller logOption.Caller `json:"caller"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	if entry.Caller.Line != line+1 {
+		t.Errorf("unexpected caller = %+v", entry.Caller)
+	}
+}
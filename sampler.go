@@ -0,0 +1,77 @@
+package nlogger
+
+import (
+	"context"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"sync"
+	"time"
+)
+
+// NewSamplingPrinter creates a Printer that limits entries with the same level and message. In each tick,
+// the first n entries are printed, and thereafter only every m-th entry is printed. If thereafter is 0,
+// then entries after the first n entries are dropped until the next tick
+func NewSamplingPrinter(p Printer, tick time.Duration, first, thereafter int) *samplingPrinter {
+	return &samplingPrinter{
+		printer:    p,
+		tick:       tick,
+		first:      first,
+		thereafter: thereafter,
+		counts:     make(map[samplingKey]int),
+	}
+}
+
+type samplingKey struct {
+	level level.LogLevel
+	msg   string
+}
+
+type samplingPrinter struct {
+	printer    Printer
+	tick       time.Duration
+	first      int
+	thereafter int
+
+	mu        sync.Mutex
+	counts    map[samplingKey]int
+	resetTime time.Time
+}
+
+func (s *samplingPrinter) Print(namespace string, lv level.LogLevel, msg string, options *logOption.Options) {
+	if !s.sample(lv, msg) {
+		return
+	}
+	s.printer.Print(namespace, lv, msg, options)
+}
+
+// Sync flushes underlying printer
+func (s *samplingPrinter) Sync() error {
+	return syncOf(s.printer)
+}
+
+// Close closes underlying printer
+func (s *samplingPrinter) Close(ctx context.Context) error {
+	return closeOf(ctx, s.printer)
+}
+
+// sample returns true if entry should be printed
+func (s *samplingPrinter) sample(lv level.LogLevel, msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Reset counter on every tick
+	now := time.Now()
+	if now.After(s.resetTime) {
+		s.counts = make(map[samplingKey]int)
+		s.resetTime = now.Add(s.tick)
+	}
+
+	k := samplingKey{level: lv, msg: msg}
+	n := s.counts[k] + 1
+	s.counts[k] = n
+
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
@@ -0,0 +1,43 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSamplingPrinter(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewSamplingPrinter(nlogger.NewStdLogPrinter(buf, 0), time.Minute, 2, 3)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Info))
+
+	for i := 1; i <= 8; i++ {
+		log.Infof("sampled %d", i)
+		log.Warn("other message")
+	}
+
+	// First 2 entries are printed, then every 3rd entry
+	expected := " [INFO] sampled 1\n [INFO] sampled 2\n [INFO] sampled 5\n [INFO] sampled 8\n"
+	lines := strings.SplitAfter(buf.String(), "\n")
+	var sampled string
+	var others int
+	for _, line := range lines {
+		if strings.Contains(line, "sampled") {
+			sampled += line
+		} else if strings.Contains(line, "other message") {
+			others++
+		}
+	}
+
+	if sampled != expected {
+		t.Errorf("unexpected sampled output = %s", sampled)
+	}
+
+	if others != 4 {
+		t.Errorf("unexpected count of other message = %d", others)
+	}
+}
@@ -0,0 +1,2 @@
+// Package logSlog bridges nlogger and log/slog. It requires Go 1.21 or later.
+package logSlog
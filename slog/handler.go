@@ -0,0 +1,211 @@
+//go:build go1.21
+// +build go1.21
+
+package logSlog
+
+import (
+	"context"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"log/slog"
+	"runtime"
+)
+
+// HandlerOptions are options for Handler
+type HandlerOptions struct {
+	// Level is the minimum level to be handled. If nil, level is checked with the Logger if it implements
+	// nlogger.LevelEnabler
+	Level slog.Leveler
+
+	// AddSource set caller of the record to entry
+	AddSource bool
+}
+
+// Handler is a slog.Handler that forwards records to a nlogger.Logger. Attributes are set as metadata,
+// groups are set as nested metadata and attribute with key "error" or "err" that has error value is set
+// as error option
+type Handler struct {
+	logger nlogger.Logger
+	opts   HandlerOptions
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewHandler creates a slog.Handler that forwards records to logger. If logger is nil, then registered logger
+// will be retrieved on each record
+func NewHandler(logger nlogger.Logger, opts *HandlerOptions) *Handler {
+	h := Handler{logger: logger}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return &h
+}
+
+func (h *Handler) Enabled(_ context.Context, lv slog.Level) bool {
+	if h.opts.Level != nil {
+		return lv >= h.opts.Level.Level()
+	}
+
+	l := h.logger
+	if l == nil {
+		l = nlogger.Get()
+	}
+	return nlogger.Enabled(l, FromSlogLevel(lv))
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	// Get logger
+	l := h.logger
+	if l == nil {
+		l = nlogger.Get()
+	}
+
+	args := make([]logOption.SetterFunc, 0, 5)
+	if ctx != nil {
+		args = append(args, logOption.Context(ctx))
+	}
+
+	// Keep the time when record is created
+	if !r.Time.IsZero() {
+		args = append(args, logOption.Timestamp(r.Time))
+	}
+
+	// Set caller
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		c := logOption.Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+		args = append(args, func(o *logOption.Options) {
+			o.Values[logOption.CallerKey] = c
+		})
+	}
+
+	// Collect attributes from handler and record
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	// Set handler attributes, that has been resolved with its group
+	meta := make(map[string]interface{})
+	for _, a := range h.attrs {
+		if err, ok := errorAttr(a); ok {
+			args = append(args, logOption.Error(err))
+			continue
+		}
+		setAttr(meta, a)
+	}
+
+	// Set record attributes to innermost group
+	if len(h.groups) > 0 {
+		attrs = []slog.Attr{groupAttr(h.groups, attrs)}
+	}
+	for _, a := range attrs {
+		if err, ok := errorAttr(a); ok {
+			args = append(args, logOption.Error(err))
+			continue
+		}
+		setAttr(meta, a)
+	}
+
+	if len(meta) > 0 {
+		args = append(args, logOption.Metadata(meta))
+	}
+
+	switch FromSlogLevel(r.Level) {
+	case level.Fatal:
+		l.Fatal(r.Message, args...)
+	case level.Error:
+		l.Error(r.Message, args...)
+	case level.Warn:
+		l.Warn(r.Message, args...)
+	case level.Info:
+		l.Info(r.Message, args...)
+	case level.Debug:
+		l.Debug(r.Message, args...)
+	default:
+		l.Trace(r.Message, args...)
+	}
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	// Wrap attributes with current groups
+	if len(h.groups) > 0 {
+		attrs = []slog.Attr{groupAttr(h.groups, attrs)}
+	}
+
+	c := *h
+	c.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &c
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.groups = append(append([]string{}, h.groups...), name)
+	return &c
+}
+
+// errorAttr returns error value if attribute is an error option
+func errorAttr(a slog.Attr) (error, bool) {
+	if a.Key != "error" && a.Key != "err" {
+		return nil, false
+	}
+	err, ok := a.Value.Resolve().Any().(error)
+	return err, ok
+}
+
+// groupAttr wraps attributes with nested groups
+func groupAttr(groups []string, attrs []slog.Attr) slog.Attr {
+	a := slog.Attr{Key: groups[len(groups)-1], Value: slog.GroupValue(attrs...)}
+	for i := len(groups) - 2; i >= 0; i-- {
+		a = slog.Attr{Key: groups[i], Value: slog.GroupValue(a)}
+	}
+	return a
+}
+
+// setAttr set attribute to metadata. Group attribute is set as nested metadata
+func setAttr(m map[string]interface{}, a slog.Attr) {
+	v := a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if v.Kind() != slog.KindGroup {
+		// Render error as its message, since most error types are not serializable
+		if err, ok := v.Any().(error); ok {
+			m[a.Key] = err.Error()
+		} else {
+			m[a.Key] = v.Any()
+		}
+		return
+	}
+
+	// Ignore empty group
+	if len(v.Group()) == 0 {
+		return
+	}
+
+	// If group key is empty, then inline attributes
+	group := m
+	if a.Key != "" {
+		existing, ok := m[a.Key].(map[string]interface{})
+		if !ok {
+			existing = make(map[string]interface{})
+			m[a.Key] = existing
+		}
+		group = existing
+	}
+
+	for _, ga := range v.Group() {
+		setAttr(group, ga)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build go1.21
+// +build go1.21
+
+package logSlog
+
+import (
+	"github.com/nbs-go/nlogger/v2/level"
+	"log/slog"
+)
+
+// Additional slog levels to represent nlogger levels that are not defined in slog
+const (
+	LevelTrace = slog.Level(-8)
+	LevelFatal = slog.Level(12)
+)
+
+// ToSlogLevel converts level.LogLevel to slog.Level
+func ToSlogLevel(lv level.LogLevel) slog.Level {
+	switch {
+	case lv <= level.Fatal:
+		return LevelFatal
+	case lv <= level.Error:
+		return slog.LevelError
+	case lv <= level.Warn:
+		return slog.LevelWarn
+	case lv <= level.Info:
+		return slog.LevelInfo
+	case lv <= level.Debug:
+		return slog.LevelDebug
+	default:
+		return LevelTrace
+	}
+}
+
+// FromSlogLevel converts slog.Level to level.LogLevel
+func FromSlogLevel(lv slog.Level) level.LogLevel {
+	switch {
+	case lv >= LevelFatal:
+		return level.Fatal
+	case lv >= slog.LevelError:
+		return level.Error
+	case lv >= slog.LevelWarn:
+		return level.Warn
+	case lv >= slog.LevelInfo:
+		return level.Info
+	case lv >= slog.LevelDebug:
+		return level.Debug
+	default:
+		return level.Trace
+	}
+}
@@ -0,0 +1,262 @@
+//go:build go1.21
+// +build go1.21
+
+package logSlog
+
+import (
+	"context"
+	"fmt"
+	"github.com/nbs-go/nlogger/v2"
+	logContext "github.com/nbs-go/nlogger/v2/context"
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Attribute keys that are used by Logger to write built-in options
+const (
+	NamespaceKey = "namespace"
+	RequestIdKey = "requestId"
+	ErrorKey     = "error"
+)
+
+// nloggerPrefix is the prefix of functions in nlogger package. Frames of nlogger wrappers, such as the logger
+// returned by nlogger.Get, are skipped when capturing caller
+var nloggerPrefix = reflect.TypeOf(nlogger.StdLogger{}).PkgPath() + "."
+
+// Logger is a nlogger.Logger that sends entries to a slog.Handler. Metadata is sent as attributes,
+// namespace, request id and error are sent as attribute with NamespaceKey, RequestIdKey and ErrorKey.
+// Metadata and fields that are set in NewLogger and NewChild are sent in every entry. Caller of record skips
+// additional frames that are set with logOption.CallerSkip in logger or log call options.
+// Fatal only sends entry in LevelFatal and does not terminate process
+type Logger struct {
+	handler    slog.Handler
+	namespace  string
+	ctx        context.Context
+	metadata   map[string]interface{}
+	fields     []logField.Field
+	callerSkip int
+}
+
+// NewLogger creates a nlogger.Logger that sends entries to handler. If handler is nil, then handler of
+// slog.Default is used
+func NewLogger(handler slog.Handler, args ...logOption.SetterFunc) *Logger {
+	if handler == nil {
+		handler = slog.Default().Handler()
+	}
+
+	o := logOption.Evaluate(args)
+	l := Logger{
+		handler:  handler,
+		ctx:      o.Context,
+		metadata: mergeMetadata(nil, o.Metadata),
+		fields:   mergeFields(nil, o.Fields),
+	}
+	l.namespace, _ = logOption.GetString(o, logOption.NamespaceKey)
+	l.callerSkip, _ = logOption.GetInt(o, logOption.CallerSkipKey)
+	return &l
+}
+
+func (l *Logger) Fatal(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Fatal, msg, args)
+}
+
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.logf(level.Fatal, format, args)
+}
+
+func (l *Logger) Error(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Error, msg, args)
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.logf(level.Error, format, args)
+}
+
+func (l *Logger) Warn(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Warn, msg, args)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.logf(level.Warn, format, args)
+}
+
+func (l *Logger) Info(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Info, msg, args)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.logf(level.Info, format, args)
+}
+
+func (l *Logger) Debug(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Debug, msg, args)
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.logf(level.Debug, format, args)
+}
+
+func (l *Logger) Trace(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Trace, msg, args)
+}
+
+func (l *Logger) Tracef(format string, args ...interface{}) {
+	l.logf(level.Trace, format, args)
+}
+
+func (l *Logger) NewChild(args ...logOption.SetterFunc) nlogger.Logger {
+	o := logOption.Evaluate(args)
+
+	c := *l
+	if namespace, _ := logOption.GetString(o, logOption.NamespaceKey); namespace != "" {
+		c.namespace = namespace
+	}
+	if o.Context != nil {
+		c.ctx = o.Context
+	}
+	if skip, ok := logOption.GetInt(o, logOption.CallerSkipKey); ok {
+		c.callerSkip = skip
+	}
+
+	// Inherit metadata and fields, child values take precedence
+	c.metadata = mergeMetadata(l.metadata, o.Metadata)
+	c.fields = mergeFields(l.fields, o.Fields)
+	return &c
+}
+
+// Enabled returns true if handler handles entries in level lv
+func (l *Logger) Enabled(lv level.LogLevel) bool {
+	return l.handler.Enabled(l.context(nil), ToSlogLevel(lv))
+}
+
+func (l *Logger) log(outLevel level.LogLevel, msg string, args []logOption.SetterFunc) {
+	// Check level before evaluating options
+	ctx := l.context(nil)
+	lv := ToSlogLevel(outLevel)
+	if !l.handler.Enabled(ctx, lv) {
+		return
+	}
+
+	l.write(lv, msg, logOption.Evaluate(args))
+}
+
+func (l *Logger) logf(outLevel level.LogLevel, format string, args []interface{}) {
+	ctx := l.context(nil)
+	lv := ToSlogLevel(outLevel)
+	if !l.handler.Enabled(ctx, lv) {
+		return
+	}
+
+	l.write(lv, format, logOption.NewFormatOptions(args...))
+}
+
+func (l *Logger) write(lv slog.Level, msg string, o *logOption.Options) {
+	if len(o.FmtArgs) > 0 {
+		msg = fmt.Sprintf(msg, o.FmtArgs...)
+	}
+
+	skip, _ := logOption.GetInt(o, logOption.CallerSkipKey)
+	r := slog.NewRecord(time.Now(), lv, msg, callerPC(skip+l.callerSkip))
+
+	// Set built-in options as attributes
+	ctx := l.context(o.Context)
+	if l.namespace != "" {
+		r.AddAttrs(slog.String(NamespaceKey, l.namespace))
+	}
+	if reqId := logContext.GetRequestId(ctx); reqId != "" {
+		r.AddAttrs(slog.String(RequestIdKey, reqId))
+	}
+	if err := logOption.GetError(o, logOption.ErrorKey); err != nil {
+		r.AddAttrs(slog.Any(ErrorKey, err))
+	}
+
+	// Set metadata as attributes, sorted by key. Lazy values are computed
+	meta := logOption.ResolveMetadata(mergeMetadata(l.metadata, o.Metadata))
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		r.AddAttrs(slog.Any(k, meta[k]))
+	}
+
+	// Set typed fields as attributes in the order they are added
+	for _, f := range mergeFields(l.fields, o.Fields) {
+		r.AddAttrs(slog.Any(f.Key, f.Value()))
+	}
+
+	_ = l.handler.Handle(ctx, r)
+}
+
+// context returns context of log call, or context of logger if not set
+func (l *Logger) context(ctx context.Context) context.Context {
+	if ctx != nil {
+		return ctx
+	}
+	if l.ctx != nil {
+		return l.ctx
+	}
+	return context.Background()
+}
+
+// mergeMetadata returns a new map of parent metadata overridden by child metadata
+func mergeMetadata(parent, child map[string]interface{}) map[string]interface{} {
+	if len(parent)+len(child) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, len(parent)+len(child))
+	for k, v := range parent {
+		result[k] = v
+	}
+	for k, v := range child {
+		result[k] = v
+	}
+	return result
+}
+
+// mergeFields returns a new slice of parent fields followed by child fields. Child field replaces parent field
+// with the same key at its position
+func mergeFields(parent, child []logField.Field) []logField.Field {
+	if len(parent)+len(child) == 0 {
+		return nil
+	}
+
+	result := make([]logField.Field, len(parent), len(parent)+len(child))
+	copy(result, parent)
+	for _, f := range child {
+		replaced := false
+		for i := range parent {
+			if result[i].Key == f.Key {
+				result[i] = f
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// callerPC returns program counter of logging method caller. Frames of nlogger package are skipped
+func callerPC(skip int) uintptr {
+	// Skip runtime.Callers, callerPC, write, log and logging method
+	var pcs [1]uintptr
+	for i := 5 + skip; runtime.Callers(i, pcs[:]) > 0; i++ {
+		frame, _ := runtime.CallersFrames(pcs[:]).Next()
+		if !strings.HasPrefix(frame.Function, nloggerPrefix) {
+			return pcs[0]
+		}
+	}
+	return 0
+}
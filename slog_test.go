@@ -0,0 +1,205 @@
+//go:build go1.21
+// +build go1.21
+
+package nlogger_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nbs-go/nlogger/v2"
+	logContext "github.com/nbs-go/nlogger/v2/context"
+	logField "github.com/nbs-go/nlogger/v2/field"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	logSlog "github.com/nbs-go/nlogger/v2/slog"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimestampKey("")), logOption.Level(level.Debug),
+		logOption.WithNamespace("slog"))
+	logger := slog.New(logSlog.NewHandler(l, nil))
+
+	// Level must be filtered by nlogger.Logger
+	logger.Log(context.Background(), logSlog.LevelTrace, "this should not appear")
+	if buf.Len() > 0 {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+
+	ctx := logContext.SetRequestId(context.Background(), "req-1")
+	logger.With("service", "api", "err", errors.New("timeout")).WithGroup("http").With("method", "GET").
+		ErrorContext(ctx, "request failed", slog.Int("status", 504), slog.Group("empty"))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"level":     "Error",
+		"namespace": "slog",
+		"message":   "request failed",
+		"requestId": "req-1",
+		"error":     "timeout",
+		"service":   "api",
+		"http": map[string]interface{}{
+			"method": "GET",
+			"status": float64(504),
+		},
+	}
+	if !reflect.DeepEqual(entry, expected) {
+		t.Errorf("unexpected entry.\nExpected = %v\nActual   = %v", expected, entry)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	h := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: logSlog.LevelTrace,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	var l nlogger.Logger = logSlog.NewLogger(h, logOption.WithNamespace("parent"))
+	ctx := logContext.SetRequestId(context.Background(), "req-1")
+	child := l.NewChild(logOption.WithNamespace("child"), logOption.Context(ctx))
+	child.Warn("hello %s", logOption.Format("world"), logOption.AddMetadata("key", "value"),
+		logOption.Error(errors.New("warning")))
+	l.Tracef("trace %d", 1)
+
+	expected := `{"level":"WARN","msg":"hello world","namespace":"child","requestId":"req-1","error":"warning","key":"value"}` +
+		"\n" + `{"level":"DEBUG-4","msg":"trace 1","namespace":"parent"}` + "\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
+
+func TestSlog_Enabled(t *testing.T) {
+	l := nlogger.NewStdLogger(nlogger.NewJSONPrinter(&bytes.Buffer{}), logOption.Level(level.Info))
+	h := logSlog.NewHandler(l, nil)
+
+	// Level is checked with nlogger.Logger if handler level is not set
+	if h.Enabled(context.Background(), slog.LevelDebug) || !h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Errorf("unexpected enabled levels of handler")
+	}
+
+	sl := logSlog.NewLogger(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	if nlogger.Enabled(sl, level.Info) || !nlogger.Enabled(sl, level.Warn) {
+		t.Errorf("unexpected enabled levels of logger")
+	}
+}
+
+func TestSlogLogger_Lazy(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l := logSlog.NewLogger(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	l.Info("lazy", logOption.AddLazyMetadata("meta", func() interface{} { return 1 }),
+		logOption.Fields(logField.Lazy("field", func() interface{} { return "v" })))
+
+	if expected := `{"level":"INFO","msg":"lazy","meta":1,"field":"v"}` + "\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+}
+
+func TestSlogLogger_ChildMetadata(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l := logSlog.NewLogger(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}), logOption.AddMetadata("app", "test"))
+
+	child := l.NewChild(logOption.AddMetadata("key", "parent"), logOption.Fields(logField.String("f", "parent")))
+	child.NewChild(logOption.Fields(logField.String("f", "child"), logField.Int("n", 1))).
+		Info("child", logOption.AddMetadata("key", "call"))
+	child.Info("parent")
+
+	expected := `{"level":"INFO","msg":"child","app":"test","key":"call","f":"child","n":1}` + "\n" +
+		`{"level":"INFO","msg":"parent","app":"test","key":"parent","f":"parent"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output.\nExpected = %s\nActual   = %s", expected, buf.String())
+	}
+}
+
+func TestSlogHandler_Time(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf, nlogger.WithTimeFormat(time.RFC3339Nano)),
+		logOption.Level(level.Info))
+
+	// Record time is used as entry timestamp
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	r := slog.NewRecord(created, slog.LevelInfo, "hello", 0)
+	if err := logSlog.NewHandler(l, nil).Handle(context.Background(), r); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	var entry struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+	if !entry.Timestamp.Equal(created) {
+		t.Errorf("unexpected timestamp = %s", entry.Timestamp)
+	}
+}
+
+func TestSlogLogger_Source(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	l := logSlog.NewLogger(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true}))
+	restore := nlogger.Replace(l)
+	defer restore()
+
+	wrapper := func(msg string) {
+		l.Info(msg, logOption.CallerSkip(1))
+	}
+
+	l.Info("direct")
+	nlogger.Get().Info("proxy")
+	nlogger.NewChild(logOption.WithNamespace("child")).Infof("proxy %s", "child")
+	nlogger.Log(nlogger.Get(), level.Info, "fields")
+	wrapper("wrapper")
+
+	s := bufio.NewScanner(buf)
+	count := 0
+	for s.Scan() {
+		count++
+		var entry struct {
+			Msg    string `json:"msg"`
+			Source struct {
+				Function string `json:"function"`
+			} `json:"source"`
+		}
+		if err := json.Unmarshal(s.Bytes(), &entry); err != nil {
+			t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+		}
+		if fn := entry.Source.Function; !strings.HasSuffix(fn, ".TestSlogLogger_Source") {
+			t.Errorf("unexpected source function of %q = %s", entry.Msg, fn)
+		}
+	}
+	if count != 5 {
+		t.Errorf("unexpected entries count = %d", count)
+	}
+}
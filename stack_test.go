@@ -0,0 +1,100 @@
+package nlogger_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// stackError is an error that carries program counters like github.com/pkg/errors
+type stackError struct {
+	msg string
+	pcs []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.msg
+}
+
+func (e *stackError) StackTrace() []uintptr {
+	return e.pcs
+}
+
+func newStackError(msg string) error {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(1, pcs)
+	return &stackError{msg: msg, pcs: pcs[:n]}
+}
+
+// formattedStackError is an error that returns stack trace as a formattable value
+type formattedStackError struct{}
+
+func (formattedStackError) Error() string {
+	return "formatted stack error"
+}
+
+func (formattedStackError) StackTrace() fmt.Stringer {
+	return stackString("\nmain.origin\n\tmain.go:10")
+}
+
+type stackString string
+
+func (s stackString) String() string {
+	return string(s)
+}
+
+func TestStdLogger_WithStack(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.Level(level.Debug))
+
+	// Stack trace must not be captured if not enabled
+	log.Error("without stack")
+	if strings.Contains(buf.String(), "Stack:") {
+		t.Errorf("unexpected stack trace is captured. Output = %s", buf.String())
+	}
+
+	// Capture stack trace at call site
+	buf.Reset()
+	log.Info("with stack", logOption.WithStack())
+	if !strings.Contains(buf.String(), "  > Stack:\n    github.com/nbs-go/nlogger/v2_test.TestStdLogger_WithStack\n") {
+		t.Errorf("unexpected stack trace. Output = %s", buf.String())
+	}
+}
+
+func TestStdLogger_StackLevel(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := nlogger.NewStdLogger(nlogger.NewJSONPrinter(buf), logOption.Level(level.Debug),
+		logOption.StackLevel(level.Error))
+	child := log.NewChild(logOption.WithNamespace("child"))
+
+	// Stack trace must not be captured below policy level
+	child.Warn("without stack")
+	if strings.Contains(buf.String(), `"stack"`) {
+		t.Errorf("unexpected stack trace is captured. Output = %s", buf.String())
+	}
+
+	// Stack trace must be extracted from wrapped error
+	buf.Reset()
+	err := fmt.Errorf("wrapped: %w", newStackError("origin"))
+	child.Error("with error stack", logOption.Error(err))
+	if !strings.Contains(buf.String(), `"stack":"github.com/nbs-go/nlogger/v2_test.newStackError\n`) {
+		t.Errorf("unexpected stack trace. Output = %s", buf.String())
+	}
+}
+
+func TestErrorStack(t *testing.T) {
+	stack, ok := logOption.ErrorStack(formattedStackError{})
+	if !ok || stack != "main.origin\n\tmain.go:10" {
+		t.Errorf("unexpected stack trace = %s", stack)
+	}
+
+	if _, ok = logOption.ErrorStack(errors.New("no stack")); ok {
+		t.Errorf("unexpected stack trace found")
+	}
+}
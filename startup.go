@@ -0,0 +1,293 @@
+package nlogger
+
+import (
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"os"
+	"sync"
+	"time"
+)
+
+// EnableStartupBuffer set Get to buffer log entries in memory until a logger is registered. Buffered entries are
+// replayed with their original timestamp and options to the registered logger. If no logger is registered
+// before timeout, or FlushStartupBuffer is called, then buffered entries are written to stderr.
+// Size limits number of buffered entries, when it is exceeded the oldest entries are dropped.
+// Fatal behaviour that is set in a FATAL log call or its child logger is executed at the call site after buffer is
+// flushed to stderr. Fatal behaviour of the registered logger is not executed on replay.
+// It must be called before any log is written, e.g. in init() of main package
+func EnableStartupBuffer(size int, timeout time.Duration) {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+
+	// If logger has been registered, then buffer is not required
+	if log.logger != nil || log.startup != nil {
+		return
+	}
+
+	b := newStartupBuffer(size)
+	if timeout > 0 {
+		b.timer = time.AfterFunc(timeout, func() {
+			flushStartupBuffer(b)
+		})
+	}
+
+	log.startup = b
+	log.generation++
+}
+
+// FlushStartupBuffer writes buffered entries to stderr if no logger has been registered.
+// It should be deferred in main function, so buffered entries will not be lost on process exit
+func FlushStartupBuffer() {
+	log.mu.RLock()
+	b := log.startup
+	log.mu.RUnlock()
+
+	if b != nil {
+		flushStartupBuffer(b)
+	}
+}
+
+// flushStartupBuffer deactivate buffer and write buffered entries to stderr
+func flushStartupBuffer(b *startupBuffer) {
+	// Construct logger without holding the lock, since driver factory may call this package
+	l := newFallbackLogger(os.Stderr)
+
+	log.mu.Lock()
+	if log.startup != b {
+		log.mu.Unlock()
+		return
+	}
+	log.startup = nil
+	// Buffer is only resolved if no logger is set
+	if log.logger == nil {
+		log.generation++
+	}
+	log.mu.Unlock()
+
+	b.replay(l)
+}
+
+// bufferedEntry is a log call that is captured by bufferLogger
+type bufferedEntry struct {
+	chain     [][]logOption.SetterFunc
+	level     level.LogLevel
+	msg       string
+	args      []logOption.SetterFunc
+	fmtArgs   []interface{}
+	formatted bool
+	timestamp time.Time
+}
+
+// fallbackBufferSize is the size of buffer that holds entries while fallback logger is constructed
+const fallbackBufferSize = 1024
+
+// newStartupBuffer creates startup buffer that holds up to size entries
+func newStartupBuffer(size int) *startupBuffer {
+	if size <= 0 {
+		size = 1
+	}
+
+	b := startupBuffer{size: size}
+	b.root = &bufferLogger{buffer: &b}
+	return &b
+}
+
+type startupBuffer struct {
+	mu      sync.Mutex
+	root    *bufferLogger
+	size    int
+	entries []bufferedEntry
+	dropped int
+	done    bool
+	timer   *time.Timer
+}
+
+// add append entry to buffer. If buffer has been replayed, it returns false
+func (b *startupBuffer) add(e bufferedEntry) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.done {
+		return false
+	}
+
+	// Drop the oldest entry if buffer is full
+	if len(b.entries) >= b.size {
+		b.entries = b.entries[1:]
+		b.dropped++
+	}
+	b.entries = append(b.entries, e)
+	return true
+}
+
+// replay writes buffered entries to l. Buffer will no longer accept entries after replayed
+func (b *startupBuffer) replay(l Logger) {
+	b.mu.Lock()
+	if b.done {
+		b.mu.Unlock()
+		return
+	}
+	b.done = true
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+	entries, dropped := b.entries, b.dropped
+	b.entries = nil
+	b.mu.Unlock()
+
+	if dropped > 0 {
+		l.Warnf("%s: %d early log entries are dropped, since startup buffer is full", pkgNamespace, dropped)
+	}
+
+	for _, e := range entries {
+		e.replay(l, true)
+	}
+}
+
+// replay writes entry to l with its original timestamp. If buffered is true, then fatal behaviour of l is not
+// executed, since fatal behaviour that is set in log call has been executed at the call site
+func (e *bufferedEntry) replay(l Logger, buffered bool) {
+	for _, args := range e.chain {
+		l = l.NewChild(args...)
+	}
+
+	// Compose options with original timestamp
+	var args []logOption.SetterFunc
+	if e.formatted {
+		args = []logOption.SetterFunc{logOption.Format(e.fmtArgs...), logOption.Timestamp(e.timestamp)}
+	} else {
+		args = make([]logOption.SetterFunc, 0, len(e.args)+1)
+		args = append(append(args, e.args...), logOption.Timestamp(e.timestamp))
+	}
+
+	switch e.level {
+	case level.Fatal:
+		// Print only, fatal action of l must not be executed far from the original call site
+		if buffered {
+			args = append(args, logOption.OnFatal(logOption.FatalNone), logOption.FatalHook(nil))
+		}
+		l.Fatal(e.msg, args...)
+	case level.Error:
+		l.Error(e.msg, args...)
+	case level.Warn:
+		l.Warn(e.msg, args...)
+	case level.Info:
+		l.Info(e.msg, args...)
+	case level.Debug:
+		l.Debug(e.msg, args...)
+	default:
+		l.Trace(e.msg, args...)
+	}
+}
+
+// bufferLogger is a Logger that writes entries to startup buffer. If buffer has been replayed, then entries are
+// written to registered logger
+type bufferLogger struct {
+	buffer *startupBuffer
+	chain  [][]logOption.SetterFunc
+}
+
+func (l *bufferLogger) log(lv level.LogLevel, msg string, args []logOption.SetterFunc) bool {
+	e := bufferedEntry{chain: l.chain, level: lv, msg: msg, args: args, timestamp: time.Now()}
+	return l.write(&e)
+}
+
+func (l *bufferLogger) logf(lv level.LogLevel, format string, args []interface{}) bool {
+	e := bufferedEntry{chain: l.chain, level: lv, msg: format, fmtArgs: args, formatted: true, timestamp: time.Now()}
+	return l.write(&e)
+}
+
+// write adds entry to buffer. It returns false if buffer has been replayed and entry is written to current logger
+func (l *bufferLogger) write(e *bufferedEntry) bool {
+	if l.buffer.add(*e) {
+		return true
+	}
+
+	// If buffer has been replayed, then write to current logger
+	target, _ := current()
+	e.replay(target, false)
+	return false
+}
+
+// handleFatal executes fatal behaviour that is set in log call or in child logger options. Buffer is flushed
+// before, so buffered entries are written before the process is terminated
+func (l *bufferLogger) handleFatal(msg string, args []logOption.SetterFunc, fmtArgs []interface{}) {
+	options := logOption.NewFormatOptions(fmtArgs...)
+	for _, chainArgs := range l.chain {
+		for _, fn := range chainArgs {
+			fn(options)
+		}
+	}
+	for _, fn := range args {
+		fn(options)
+	}
+
+	// Fatal behaviour of the logger that will be registered is unknown, so it is not executed
+	_, hasAction := options.Values[logOption.FatalActionKey]
+	_, hasHook := options.Values[logOption.FatalHookKey]
+	if !hasAction && !hasHook {
+		return
+	}
+
+	h := newFatalHandler(options)
+	flushStartupBuffer(l.buffer)
+	h.handle(nil, formatMessage(msg, options))
+}
+
+func (l *bufferLogger) Fatal(msg string, args ...logOption.SetterFunc) {
+	if l.log(level.Fatal, msg, args) {
+		l.handleFatal(msg, args, nil)
+	}
+}
+
+func (l *bufferLogger) Fatalf(format string, args ...interface{}) {
+	if l.logf(level.Fatal, format, args) {
+		l.handleFatal(format, nil, args)
+	}
+}
+
+func (l *bufferLogger) Error(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Error, msg, args)
+}
+
+func (l *bufferLogger) Errorf(format string, args ...interface{}) {
+	l.logf(level.Error, format, args)
+}
+
+func (l *bufferLogger) Warn(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Warn, msg, args)
+}
+
+func (l *bufferLogger) Warnf(format string, args ...interface{}) {
+	l.logf(level.Warn, format, args)
+}
+
+func (l *bufferLogger) Info(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Info, msg, args)
+}
+
+func (l *bufferLogger) Infof(format string, args ...interface{}) {
+	l.logf(level.Info, format, args)
+}
+
+func (l *bufferLogger) Debug(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Debug, msg, args)
+}
+
+func (l *bufferLogger) Debugf(format string, args ...interface{}) {
+	l.logf(level.Debug, format, args)
+}
+
+func (l *bufferLogger) Trace(msg string, args ...logOption.SetterFunc) {
+	l.log(level.Trace, msg, args)
+}
+
+func (l *bufferLogger) Tracef(format string, args ...interface{}) {
+	l.logf(level.Trace, format, args)
+}
+
+func (l *bufferLogger) NewChild(args ...logOption.SetterFunc) Logger {
+	chain := make([][]logOption.SetterFunc, len(l.chain), len(l.chain)+1)
+	copy(chain, l.chain)
+	return &bufferLogger{buffer: l.buffer, chain: append(chain, args)}
+}
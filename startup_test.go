@@ -0,0 +1,189 @@
+package nlogger_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/nbs-go/nlogger/v2"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartupBuffer_Register(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	nlogger.EnableStartupBuffer(2, time.Minute)
+	child := nlogger.NewChild(logOption.WithNamespace("early"))
+	child.Info("dropped")
+	child.Info("first", logOption.AddMetadata("key", "value"))
+	nlogger.Get().Errorf("second %d", 2)
+	buffered := time.Now()
+
+	// Register logger and replay entries
+	time.Sleep(10 * time.Millisecond)
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewJSONPrinter(buf, nlogger.WithTimeFormat(time.RFC3339Nano))
+	nlogger.Register(nlogger.NewStdLogger(p, logOption.Level(level.Info)))
+	child.Info("third")
+
+	type entry struct {
+		Timestamp time.Time `json:"timestamp"`
+		Level     string    `json:"level"`
+		Namespace string    `json:"namespace"`
+		Message   string    `json:"message"`
+		Key       string    `json:"key"`
+	}
+
+	var entries []entry
+	s := bufio.NewScanner(buf)
+	for s.Scan() {
+		var e entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if len(entries) != 4 {
+		t.Fatalf("unexpected entries = %+v", entries)
+	}
+
+	if !strings.Contains(entries[0].Message, "1 early log entries are dropped") {
+		t.Errorf("unexpected dropped warning = %+v", entries[0])
+	}
+
+	if e := entries[1]; e.Message != "first" || e.Namespace != "early" || e.Key != "value" ||
+		e.Timestamp.After(buffered) {
+		t.Errorf("unexpected first entry = %+v", e)
+	}
+
+	if e := entries[2]; e.Message != "second 2" || e.Level != "Error" || e.Timestamp.After(buffered) {
+		t.Errorf("unexpected second entry = %+v", e)
+	}
+
+	if e := entries[3]; e.Message != "third" || e.Namespace != "early" || e.Timestamp.Before(buffered) {
+		t.Errorf("unexpected third entry = %+v", e)
+	}
+}
+
+func TestStartupBuffer_Timeout(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	// Redirect stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() {
+		os.Stderr = stderr
+	}()
+
+	nlogger.EnableStartupBuffer(10, 10*time.Millisecond)
+	nlogger.Get().Error("buffered entry")
+
+	// Wait until buffered entry is written to stderr
+	lines := make(chan string)
+	go func() {
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			lines <- s.Text()
+		}
+		close(lines)
+	}()
+
+	select {
+	case line := <-lines:
+		if !strings.Contains(line, "[ERROR] buffered entry") {
+			t.Errorf("unexpected stderr output = %s", line)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("buffered entry is not flushed to stderr")
+	}
+	_ = w.Close()
+}
+
+func TestStartupBuffer_ReplayFatal(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	nlogger.EnableStartupBuffer(10, time.Minute)
+	nlogger.Get().Fatal("early fatal")
+	nlogger.Get().Fatalf("early fatal %d", 2)
+
+	hookCalled := false
+	buf := bytes.NewBuffer(nil)
+	l := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.OnFatal(logOption.FatalPanic),
+		logOption.FatalHook(func(string) { hookCalled = true }))
+
+	// Fatal action must not be executed on replay
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("unexpected panic on register = %v", r)
+			}
+		}()
+		nlogger.Register(l)
+	}()
+
+	if hookCalled {
+		t.Errorf("expected fatal hook is not called on replay")
+	}
+	if expected := "[FATAL] early fatal\n[FATAL] early fatal 2\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %q", buf.String())
+	}
+}
+
+func TestStartupBuffer_FatalInCall(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	exitCode := -1
+	restore := nlogger.SetExitFunc(func(code int) {
+		exitCode = code
+	})
+	defer restore()
+
+	// Redirect stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() {
+		os.Stderr = stderr
+	}()
+
+	// Fatal behaviour set in log call is executed at the call site after buffer is flushed
+	nlogger.EnableStartupBuffer(10, time.Minute)
+	nlogger.Get().Error("buffered entry")
+	nlogger.Get().Fatal("exit", logOption.OnFatal(logOption.FatalExit))
+	if exitCode != 1 {
+		t.Errorf("unexpected exit code = %d", exitCode)
+	}
+
+	// Fatal behaviour set in child logger
+	nlogger.Clear()
+	nlogger.EnableStartupBuffer(10, time.Minute)
+	nlogger.NewChild(logOption.OnFatal(logOption.FatalExit), logOption.FatalExitCode(3)).Fatalf("exit %d", 3)
+	if exitCode != 3 {
+		t.Errorf("unexpected exit code = %d", exitCode)
+	}
+	_ = w.Close()
+
+	out, _ := io.ReadAll(r)
+	for _, expected := range []string{"[ERROR] buffered entry", "[FATAL] exit\n", "[FATAL] exit 3\n"} {
+		if !strings.Contains(string(out), expected) {
+			t.Errorf("expected %q is flushed to stderr, output = %q", expected, out)
+		}
+	}
+}
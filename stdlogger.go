@@ -5,11 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	logContext "github.com/nbs-go/nlogger/v2/context"
+	logField "github.com/nbs-go/nlogger/v2/field"
 	"github.com/nbs-go/nlogger/v2/level"
 	"github.com/nbs-go/nlogger/v2/option"
 	"io"
 	stdLog "log"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 )
 
 var stdLevelPrefix = map[level.LogLevel]string{
@@ -22,10 +28,22 @@ var stdLevelPrefix = map[level.LogLevel]string{
 }
 
 type StdLogger struct {
-	level     level.LogLevel
-	printer   Printer
-	namespace string
-	ctx       context.Context
+	level      *namespaceLevel
+	levels     *namespaceLevels
+	printer    Printer
+	namespace  string
+	ctx        context.Context
+	caller     bool
+	callerSkip int
+	stack      bool
+	stackLevel level.LogLevel
+	fatal      fatalHandler
+	// metadata and fields are merged into every entry
+	metadata map[string]interface{}
+	fields   []logField.Field
+	// namespacePath is segments of namespace that passed to printer
+	namespacePath []string
+	hierarchical  bool
 }
 
 func (l *StdLogger) Fatal(msg string, args ...logOption.SetterFunc) {
@@ -37,73 +55,214 @@ func (l *StdLogger) Fatalf(format string, args ...interface{}) {
 }
 
 func (l *StdLogger) Error(msg string, args ...logOption.SetterFunc) {
+	if !l.level.Enabled(level.Error) {
+		return
+	}
 	l.print(level.Error, msg, logOption.Evaluate(args))
 }
 
 func (l *StdLogger) Errorf(format string, args ...interface{}) {
+	if !l.level.Enabled(level.Error) {
+		return
+	}
 	l.print(level.Error, format, logOption.NewFormatOptions(args...))
 }
 
 func (l *StdLogger) Warn(msg string, args ...logOption.SetterFunc) {
+	if !l.level.Enabled(level.Warn) {
+		return
+	}
 	l.print(level.Warn, msg, logOption.Evaluate(args))
 }
 
 func (l *StdLogger) Warnf(format string, args ...interface{}) {
+	if !l.level.Enabled(level.Warn) {
+		return
+	}
 	l.print(level.Warn, format, logOption.NewFormatOptions(args...))
 }
 
 func (l *StdLogger) Info(msg string, args ...logOption.SetterFunc) {
+	if !l.level.Enabled(level.Info) {
+		return
+	}
 	l.print(level.Info, msg, logOption.Evaluate(args))
 }
 
 func (l *StdLogger) Infof(format string, args ...interface{}) {
+	if !l.level.Enabled(level.Info) {
+		return
+	}
 	l.print(level.Info, format, logOption.NewFormatOptions(args...))
 }
 
 func (l *StdLogger) Debug(msg string, args ...logOption.SetterFunc) {
+	if !l.level.Enabled(level.Debug) {
+		return
+	}
 	l.print(level.Debug, msg, logOption.Evaluate(args))
 }
 
 func (l *StdLogger) Debugf(format string, args ...interface{}) {
+	if !l.level.Enabled(level.Debug) {
+		return
+	}
 	l.print(level.Debug, format, logOption.NewFormatOptions(args...))
 }
 
 func (l *StdLogger) Trace(msg string, args ...logOption.SetterFunc) {
+	if !l.level.Enabled(level.Trace) {
+		return
+	}
 	l.print(level.Trace, msg, logOption.Evaluate(args))
 }
 
 func (l *StdLogger) Tracef(format string, args ...interface{}) {
+	if !l.level.Enabled(level.Trace) {
+		return
+	}
 	l.print(level.Trace, format, logOption.NewFormatOptions(args...))
 }
 
 func (l *StdLogger) NewChild(args ...logOption.SetterFunc) Logger {
 	options := logOption.Evaluate(args)
 
-	// Override namespace if option is set
-	namespace, _ := logOption.GetString(options, logOption.NamespaceKey)
-
-	// If not set and parent has namespace, then use parent namespace
-	if namespace == "" && l.namespace != "" {
-		args = append(args, logOption.WithNamespace(l.namespace))
+	// Inherit hierarchical namespace option if not set
+	hierarchical := l.hierarchical
+	if v, ok := logOption.GetBool(options, logOption.HierarchicalNamespaceKey); ok {
+		hierarchical = v
 	}
 
-	// Override level arguments
-	args = append(args, logOption.Level(l.level))
+	// Override namespace if option is set. If not set, then use parent namespace.
+	// In hierarchical mode, namespace is joined with parent namespace
+	namespace, _ := logOption.GetString(options, logOption.NamespaceKey)
+	switch {
+	case namespace == "":
+		namespace = l.namespace
+	case hierarchical && l.namespace != "":
+		namespace = l.namespace + "." + namespace
+	}
+	if namespace != "" {
+		args = append(args, logOption.WithNamespace(namespace))
+	}
 
 	// Initiate new logger
 	cl := NewStdLogger(l.printer, args...)
 
+	// Share levels with parent. If namespace is overridden, then use level of the namespace that inherit root level
+	cl.levels = l.levels
+	cl.hierarchical = hierarchical
+	if namespace == l.namespace {
+		cl.level = l.level
+	} else {
+		cl.level = l.levels.levelOf(namespace)
+	}
+
 	// Set context if available
 	if ctx := options.Context; ctx != nil {
 		cl.ctx = ctx
 	}
 
+	// Inherit caller capture options if not set
+	if _, ok := options.Values[logOption.CallerEnabledKey]; !ok {
+		cl.caller = l.caller
+	}
+	if _, ok := options.Values[logOption.CallerSkipKey]; !ok {
+		cl.callerSkip = l.callerSkip
+	}
+
+	// Inherit stack trace capture options if not set
+	if _, ok := options.Values[logOption.StackEnabledKey]; !ok {
+		cl.stack = l.stack
+	}
+	if _, ok := options.Values[logOption.StackLevelKey]; !ok {
+		cl.stackLevel = l.stackLevel
+	}
+
+	// Inherit fatal behaviour and override if set
+	cl.fatal = l.fatal
+	cl.fatal.override(options)
+
+	// Inherit metadata and fields, child values take precedence
+	cl.metadata = mergeMetadata(l.metadata, options.Metadata)
+	cl.fields = mergeFields(l.fields, options.Fields)
+
 	return cl
 }
 
+// With creates a child logger that writes fields in every entry
+func (l *StdLogger) With(fields ...logField.Field) Logger {
+	return l.NewChild(logOption.Fields(copyFields(fields)...))
+}
+
+// SetLevel change level of logger at runtime. Since level is shared by reference, the change will affect
+// all loggers in the same namespace. If logger is the root logger, the change will affect all namespaces
+// that their level are not overridden
+func (l *StdLogger) SetLevel(lv level.LogLevel) {
+	l.level.Set(lv)
+}
+
+// GetLevel returns current level of logger
+func (l *StdLogger) GetLevel() level.LogLevel {
+	return l.level.Get()
+}
+
+// SetNamespaceLevel override level of loggers in a namespace within the logger tree
+func (l *StdLogger) SetNamespaceLevel(namespace string, lv level.LogLevel) {
+	l.levels.set(namespace, lv)
+}
+
+// ResetNamespaceLevel clear level override of a namespace, so it will inherit root level
+func (l *StdLogger) ResetNamespaceLevel(namespace string) {
+	l.levels.unset(namespace)
+}
+
+// NamespaceLevel returns effective level of a namespace and whether the level is overridden
+func (l *StdLogger) NamespaceLevel(namespace string) (level.LogLevel, bool) {
+	return l.levels.get(namespace)
+}
+
+// NamespaceLevels returns effective level of each namespace that has level override within the logger tree
+func (l *StdLogger) NamespaceLevels() map[string]level.LogLevel {
+	return l.levels.all()
+}
+
+// Sync flushes entries buffered by printer
+func (l *StdLogger) Sync() error {
+	return syncOf(l.printer)
+}
+
+// Close flushes and closes printer. Printer is shared with child loggers, so they must not be used after closed
+func (l *StdLogger) Close(ctx context.Context) error {
+	err := syncOf(l.printer)
+	if closeErr := closeOf(ctx, l.printer); closeErr != nil {
+		err = closeErr
+	}
+	return err
+}
+
 func (l *StdLogger) print(outLevel level.LogLevel, msg string, options *logOption.Options) {
+	// Handle fatal behaviour after entry is printed, even if the entry is not printed.
+	// Behaviour that is set in log call overrides logger behaviour
+	if outLevel == level.Fatal {
+		fatal := l.fatal
+		fatal.override(options)
+		if fatal.active() {
+			// Compute lazy values before, so they are computed once for printer and fatal handler
+			resolveLazy(options)
+			defer func() {
+				fatal.handle(l.printer, formatMessage(msg, options))
+			}()
+		}
+	}
+
+	// Set timestamp if not set
+	if _, ok := options.Values[logOption.TimestampKey]; !ok {
+		options.Values[logOption.TimestampKey] = time.Now()
+	}
+
 	// if output level is greater than log level, don't print
-	if outLevel > l.level {
+	if !l.level.Enabled(outLevel) {
 		return
 	}
 
@@ -112,9 +271,71 @@ func (l *StdLogger) print(outLevel level.LogLevel, msg string, options *logOptio
 		options.Context = l.ctx
 	}
 
+	// Pass namespace segments to printer
+	if l.namespacePath != nil {
+		options.Values[logOption.NamespacePathKey] = l.namespacePath
+	}
+
+	// Merge logger metadata and fields, values that set in log call take precedence
+	if len(l.metadata) > 0 {
+		options.Metadata = mergeMetadata(l.metadata, options.Metadata)
+	}
+	if len(l.fields) > 0 {
+		options.Fields = mergeFields(l.fields, options.Fields)
+	}
+
+	// Compute lazy values, since entry will be printed
+	resolveLazy(options)
+
+	// Capture caller if enabled in logger or in log call
+	skip, _ := logOption.GetInt(options, logOption.CallerSkipKey)
+	skip += l.callerSkip
+	enabled, _ := logOption.GetBool(options, logOption.CallerEnabledKey)
+	if l.caller || enabled {
+		if c, ok := captureCaller(skip); ok {
+			options.Values[logOption.CallerKey] = c
+		}
+	}
+
+	// Capture stack trace if enabled in logger, in log call or by level policy
+	enabled, _ = logOption.GetBool(options, logOption.StackEnabledKey)
+	if l.stack || enabled || outLevel <= l.stackLevel {
+		options.Values[logOption.StackKey] = captureStack(options, skip)
+	}
+
 	l.printer.Print(l.namespace, outLevel, msg, options)
 }
 
+// captureCaller returns caller of logging method. Skip is the number of additional stack frames to skip
+func captureCaller(skip int) (logOption.Caller, bool) {
+	// Skip runtime.Callers, captureCaller, StdLogger.print and logging method
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(4+skip, pcs) == 0 {
+		return logOption.Caller{}, false
+	}
+
+	frame, _ := runtime.CallersFrames(pcs).Next()
+	return logOption.Caller{
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}, true
+}
+
+// captureStack returns stack trace carried by error option, or stack trace of logging method caller
+func captureStack(options *logOption.Options, skip int) string {
+	if logErr := logOption.GetError(options, logOption.ErrorKey); logErr != nil {
+		if stack, ok := logOption.ErrorStack(logErr); ok {
+			return stack
+		}
+	}
+
+	// Skip runtime.Callers, captureStack, StdLogger.print and logging method
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(4+skip, pcs)
+	return logOption.FormatStack(pcs[:n])
+}
+
 func NewStdLogger(printer Printer, args ...logOption.SetterFunc) *StdLogger {
 	// Init standard logger instance
 	l := StdLogger{}
@@ -123,18 +344,49 @@ func NewStdLogger(printer Printer, args ...logOption.SetterFunc) *StdLogger {
 	o := logOption.Evaluate(args)
 
 	// Set level
-	l.level = o.Level
+	root := logOption.GetLevelVar(o, logOption.LevelVarKey)
+	if root == nil {
+		root = level.NewVar(o.Level)
+	}
+	l.levels = newNamespaceLevels(root)
+	l.level = l.levels.levelOf("")
+
+	// Set namespace level overrides
+	if m, ok := o.Values[logOption.NamespaceLevelsKey].(map[string]level.LogLevel); ok {
+		for namespace, lv := range m {
+			l.levels.set(namespace, lv)
+		}
+	}
 
 	// Get namespace
 	if namespace, _ := logOption.GetString(o, logOption.NamespaceKey); namespace != "" {
 		l.namespace = namespace
+		l.namespacePath = strings.Split(namespace, ".")
 	}
+	l.hierarchical, _ = logOption.GetBool(o, logOption.HierarchicalNamespaceKey)
 
 	// Get context
 	if ctx := o.Context; ctx != nil {
 		l.ctx = ctx
 	}
 
+	// Get caller capture options
+	l.caller, _ = logOption.GetBool(o, logOption.CallerEnabledKey)
+	l.callerSkip, _ = logOption.GetInt(o, logOption.CallerSkipKey)
+
+	// Get stack trace capture options
+	l.stack, _ = logOption.GetBool(o, logOption.StackEnabledKey)
+	if lv, ok := o.Values[logOption.StackLevelKey].(level.LogLevel); ok {
+		l.stackLevel = lv
+	}
+
+	// Get fatal behaviour
+	l.fatal = newFatalHandler(o)
+
+	// Get metadata and fields that are written in every entry
+	l.metadata = mergeMetadata(nil, o.Metadata)
+	l.fields = mergeFields(nil, o.Fields)
+
 	// Init printer if nil
 	if printer == nil {
 		l.printer = NewStdLogPrinter(os.Stdout, stdLog.LstdFlags)
@@ -145,25 +397,71 @@ func NewStdLogger(printer Printer, args ...logOption.SetterFunc) *StdLogger {
 	return &l
 }
 
-func NewStdLogPrinter(out io.Writer, flag int) *stdLogPrinter {
+func NewStdLogPrinter(out io.Writer, flag int, args ...PrinterOption) *stdLogPrinter {
 	// If writer is nil, set default writer to Stdout
 	if out == nil {
 		out = os.Stdout
 	}
 
-	// Init log.Logger
-	writer := stdLog.New(out, "", flag)
+	// Init log.Logger. Header is written by printer, so timestamp of entry is preserved
+	writer := stdLog.New(out, "", 0)
 
-	return &stdLogPrinter{writer: writer}
+	return &stdLogPrinter{
+		writer:  writer,
+		flag:    flag,
+		options: newPrinterOptions(args),
+	}
 }
 
 type stdLogPrinter struct {
-	writer *stdLog.Logger
+	mu      sync.Mutex
+	writer  *stdLog.Logger
+	flag    int
+	options *printerOptions
 }
 
-func (s *stdLogPrinter) Print(namespace string, lv level.LogLevel, msg string, options *logOption.Options) {
-	writer := s.writer
+// Sync flushes writer if supported
+func (s *stdLogPrinter) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return syncWriter(s.writer.Writer())
+}
+
+// header returns log header according to log.Logger flags, e.g. "2009/01/23 01:23:23 "
+func (s *stdLogPrinter) header(options *logOption.Options) string {
+	flag := s.flag
+	var b []byte
 
+	if flag&(stdLog.Ldate|stdLog.Ltime|stdLog.Lmicroseconds) != 0 {
+		t := entryTime(options)
+		if flag&stdLog.LUTC != 0 {
+			t = t.UTC()
+		}
+		if flag&stdLog.Ldate != 0 {
+			b = t.AppendFormat(b, "2006/01/02 ")
+		}
+		if flag&stdLog.Lmicroseconds != 0 {
+			b = t.AppendFormat(b, "15:04:05.000000 ")
+		} else if flag&stdLog.Ltime != 0 {
+			b = t.AppendFormat(b, "15:04:05 ")
+		}
+	}
+
+	// Write file from captured caller
+	if flag&(stdLog.Lshortfile|stdLog.Llongfile) != 0 {
+		if c, ok := logOption.GetCaller(options, logOption.CallerKey); ok {
+			file := c.File
+			if flag&stdLog.Lshortfile != 0 {
+				file = filepath.Base(file)
+			}
+			b = append(b, fmt.Sprintf("%s:%d: ", file, c.Line)...)
+		}
+	}
+
+	return string(b)
+}
+
+func (s *stdLogPrinter) Print(namespace string, lv level.LogLevel, msg string, options *logOption.Options) {
 	// Generate prefix
 	prefix := stdLevelPrefix[lv]
 
@@ -172,33 +470,82 @@ func (s *stdLogPrinter) Print(namespace string, lv level.LogLevel, msg string, o
 		prefix = fmt.Sprintf("%s(%s) ", prefix, namespace)
 	}
 
-	// If options is existed
-	// If formatted arguments is available, then print as formatted
-	fmtArgs := options.FmtArgs
-	if len(fmtArgs) > 0 {
-		writer.Printf(prefix+msg+"\n", fmtArgs...)
-	} else {
-		writer.Printf("%s%s\n", prefix, msg)
-	}
+	// Compose message and details of entry
+	lines := []string{prefix + formatMessage(msg, options)}
 
 	// Get request id
 	if reqId := logContext.GetRequestId(options.Context); reqId != "" {
-		writer.Printf("  > Request ID: %s\n", reqId)
+		lines = append(lines, "Request ID: "+reqId)
+	}
+
+	// Get caller
+	if c, ok := logOption.GetCaller(options, logOption.CallerKey); ok {
+		lines = append(lines, fmt.Sprintf("Caller: %s (%s)", c, c.Function))
 	}
 
 	// If error exists, then print error
 	logErr := logOption.GetError(options, logOption.ErrorKey)
 	if logErr != nil && lv <= level.Error {
-		writer.Printf("  > Error: %s\n", logErr)
+		lines = append(lines, "Error: "+logErr.Error())
+
+		// Print wrapped errors with its type
+		for _, c := range logOption.ErrorChain(logErr)[1:] {
+			lines = append(lines, fmt.Sprintf("  Caused by (%s): %s", c.Type, c.Message))
+		}
 	}
 
-	meta := options.Metadata
-	if meta != nil && len(meta) > 0 {
+	meta := s.options.metadata(options)
+	fields := uniqueFields(options.Fields)
+	if len(meta) > 0 || len(fields) > 0 {
+		// Typed fields take precedence over metadata with the same key
+		if len(fields) > 0 {
+			m := make(map[string]interface{}, len(meta))
+			for k, v := range meta {
+				m[k] = v
+			}
+			for _, f := range fields {
+				delete(m, f.Key)
+			}
+			meta = m
+		}
+
 		// Serialize to json
 		metadata, err := json.Marshal(meta)
 		// If not error, then print
 		if err == nil {
-			writer.Printf("  > Metadata: %s\n", metadata)
+			metadata = s.options.appendJSONFields(metadata, fields, nil)
+			lines = append(lines, "Metadata: "+string(metadata))
 		}
 	}
+
+	// Get stack trace
+	if stack, _ := logOption.GetString(options, logOption.StackKey); stack != "" {
+		lines = append(lines, "Stack:\n"+indent(strings.TrimRight(stack, "\n"), "    "))
+	}
+
+	header := s.header(options)
+
+	// If single line is enabled, then join all lines and write it at once
+	if s.options.singleLine {
+		for i, line := range lines {
+			lines[i] = strings.ReplaceAll(line, "\n", `\n`)
+		}
+		_ = s.writer.Output(2, header+strings.Join(lines, " | ")+"\n")
+		return
+	}
+
+	// Lock writer, so lines of an entry will not be interleaved with other entries
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, line := range lines {
+		if i > 0 {
+			line = "  > " + line
+		}
+		_ = s.writer.Output(2, header+line+"\n")
+	}
+}
+
+// indent prepends each line in s with prefix
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
 }
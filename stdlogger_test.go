@@ -1,6 +1,7 @@
 package nlogger_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,7 +10,11 @@ import (
 	logContext "github.com/nbs-go/nlogger/v2/context"
 	"github.com/nbs-go/nlogger/v2/level"
 	logOption "github.com/nbs-go/nlogger/v2/option"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 )
@@ -187,6 +192,36 @@ func TestNewStdLogPrinter_NilOut(t *testing.T) {
 	log.Debug("log message")
 }
 
+func TestNewStdLogPrinter_SingleLine(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewStdLogPrinter(buf, 0, nlogger.WithSingleLine())
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Debug), logOption.WithNamespace("single"))
+
+	ctx := logContext.SetRequestId(context.Background(), "req-1")
+	log.Error("multi\nline message",
+		logOption.Context(ctx),
+		logOption.Error(errors.New("this is error")),
+		logOption.AddMetadata("key", "value"),
+	)
+
+	expected := `[ERROR] (single) multi\nline message | Request ID: req-1 | Error: this is error | Metadata: {"key":"value"}` + "\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
+
+func TestNewStdLogPrinter_MultiLine(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewStdLogPrinter(buf, 0)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Debug))
+	log.Error("message", logOption.Error(errors.New("this is error")))
+
+	expected := "[ERROR] message\n  > Error: this is error\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
+
 func TestEvaluateOptions(t *testing.T) {
 	// Evaluate context argument
 	args := []logOption.SetterFunc{
@@ -313,3 +348,184 @@ func newCustomPrinter() *customPrinter {
 		writer: json.NewEncoder(os.Stdout),
 	}
 }
+
+func TestStdLogger_SetLevel(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	lv := level.NewVar(level.Info)
+	parent := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(buf, 0), logOption.LevelVar(lv))
+	child := parent.NewChild(logOption.WithNamespace("child"))
+	grandChild := child.NewChild()
+
+	grandChild.Debug("this should not appear")
+	if buf.Len() > 0 {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+
+	// Change level from root
+	parent.SetLevel(level.Debug)
+	grandChild.Debug("this should appear")
+	if expected := "[DEBUG] (child) this should appear\n"; buf.String() != expected {
+		t.Errorf("unexpected output = %s", buf.String())
+	}
+
+	if lv.Get() != level.Debug || parent.GetLevel() != level.Debug {
+		t.Errorf("unexpected level = %d", lv.Get())
+	}
+}
+
+func TestStdLogger_SetLevelConcurrently(t *testing.T) {
+	parent := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0), logOption.Level(level.Info))
+	child := parent.NewChild()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			parent.SetLevel(level.Trace)
+		}()
+		go func() {
+			defer wg.Done()
+			child.Trace("concurrent log")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStdLogger_NamespaceLevels(t *testing.T) {
+	root := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0), logOption.Level(level.Info),
+		logOption.NamespaceLevels(map[string]level.LogLevel{
+			"db":          level.Debug,
+			"http.client": level.Trace,
+		}))
+
+	testCases := map[string]level.LogLevel{
+		"db":              level.Debug,
+		"db.query":        level.Debug,
+		"http":            level.Info,
+		"http.client":     level.Trace,
+		"http.client.tls": level.Trace,
+		"http.server":     level.Info,
+		"dbx":             level.Info,
+	}
+
+	for namespace, expected := range testCases {
+		child := root.NewChild(logOption.WithNamespace(namespace)).(*nlogger.StdLogger)
+		if lv := child.GetLevel(); lv != expected {
+			t.Errorf("unexpected %s level. Expected = %d, Actual = %d", namespace, expected, lv)
+		}
+	}
+
+	// Override parent namespace at runtime
+	root.SetNamespaceLevel("http", level.Warn)
+	if lv, _ := root.NamespaceLevel("http.server"); lv != level.Warn {
+		t.Errorf("unexpected http.server level = %d", lv)
+	}
+}
+
+func TestStdLogger_DynamicNamespaceLevels(t *testing.T) {
+	root := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(io.Discard, 0), logOption.Level(level.Info))
+
+	// Namespaces without override are not registered
+	for i := 0; i < 100; i++ {
+		root.NewChild(logOption.WithNamespace(fmt.Sprintf("request.%d", i))).Info("handled")
+	}
+	if levels := root.NamespaceLevels(); len(levels) != 0 {
+		t.Errorf("unexpected namespace levels = %v", levels)
+	}
+
+	// Overrides that are changed at runtime apply to existing child loggers
+	pool := root.NewChild(logOption.WithNamespace("db.pool")).(*nlogger.StdLogger)
+	steps := []struct {
+		apply    func()
+		expected level.LogLevel
+	}{
+		{func() { root.SetNamespaceLevel("db", level.Debug) }, level.Debug},
+		{func() { root.SetNamespaceLevel("db.pool", level.Trace) }, level.Trace},
+		{func() { root.SetNamespaceLevel("db", level.Warn) }, level.Trace},
+		{func() { root.ResetNamespaceLevel("db.pool") }, level.Warn},
+		{func() { root.ResetNamespaceLevel("db") }, level.Info},
+	}
+	for i, s := range steps {
+		s.apply()
+		if lv := pool.GetLevel(); lv != s.expected {
+			t.Errorf("unexpected level at step %d. Expected = %d, Actual = %d", i, s.expected, lv)
+		}
+	}
+
+	// Set level of child logger overrides its namespace
+	pool.SetLevel(level.Error)
+	if lv, ok := root.NamespaceLevel("db.pool"); lv != level.Error || !ok {
+		t.Errorf("unexpected db.pool level = %d, overridden = %v", lv, ok)
+	}
+	if lv, ok := root.NamespaceLevel("db.pool.conn"); lv != level.Error || ok {
+		t.Errorf("unexpected db.pool.conn level = %d, overridden = %v", lv, ok)
+	}
+}
+
+func TestGet_NamespaceLevels(t *testing.T) {
+	nlogger.Clear()
+	defer nlogger.Clear()
+
+	_ = os.Setenv(nlogger.EnvLogLevel, "warn,db=debug")
+	defer func() {
+		_ = os.Unsetenv(nlogger.EnvLogLevel)
+	}()
+
+	l, ok := nlogger.Unwrap(nlogger.NewChild(logOption.WithNamespace("db.query"))).(*nlogger.StdLogger)
+	if !ok {
+		t.Fatalf("unexpected logger type")
+	}
+
+	if lv := l.GetLevel(); lv != level.Debug {
+		t.Errorf("unexpected level = %d", lv)
+	}
+}
+
+func TestStdLogger_Caller(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewStdLogPrinter(buf, 0, nlogger.WithSingleLine())
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Debug), logOption.WithCaller())
+
+	_, file, line, _ := runtime.Caller(0)
+	log.Debug("log with caller")
+
+	expected := fmt.Sprintf("[DEBUG] log with caller | Caller: %s/%s:%d (%s)\n", filepath.Base(filepath.Dir(file)),
+		filepath.Base(file), line+1, "github.com/nbs-go/nlogger/v2_test.TestStdLogger_Caller")
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected output.\nExpected = %s\nActual   = %s", expected, actual)
+	}
+}
+
+func TestStdLogger_CallerSkip(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	p := nlogger.NewJSONPrinter(buf)
+	log := nlogger.NewStdLogger(p, logOption.Level(level.Debug))
+
+	// Enable caller in child logger and skip wrapper function
+	child := log.NewChild(logOption.WithCaller(), logOption.CallerSkip(1))
+	wrapper := func(msg string) {
+		child.Info(msg)
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	wrapper("log from wrapper")
+
+	var entry struct {
+		Caller logOption.Caller `json:"caller"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error when parsing json entry. Error = %s", err)
+	}
+
+	if entry.Caller.Line != line+1 || entry.Caller.Function != "github.com/nbs-go/nlogger/v2_test.TestStdLogger_CallerSkip" {
+		t.Errorf("unexpected caller = %+v", entry.Caller)
+	}
+
+	// Caller must not be captured if not enabled
+	buf.Reset()
+	log.Info("log without caller")
+	if bytes.Contains(buf.Bytes(), []byte(`"caller"`)) {
+		t.Errorf("unexpected caller is captured. Output = %s", buf.String())
+	}
+}
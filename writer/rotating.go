@@ -0,0 +1,411 @@
+package logWriter
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is timestamp format in backup file name
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// compressSuffix is file extension of compressed backup
+const compressSuffix = ".gz"
+
+// RotateOption is a function that set configuration of RotatingFile
+type RotateOption func(*rotateOptions)
+
+type rotateOptions struct {
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+	maxAge     time.Duration
+	compress   bool
+	perm       os.FileMode
+}
+
+// MaxSize set maximum size of log file in bytes before it is rotated. If 0, file is not rotated by size
+func MaxSize(bytes int64) RotateOption {
+	return func(o *rotateOptions) {
+		o.maxSize = bytes
+	}
+}
+
+// Interval set duration of log file before it is rotated. If 0, file is not rotated by time
+func Interval(d time.Duration) RotateOption {
+	return func(o *rotateOptions) {
+		o.interval = d
+	}
+}
+
+// MaxBackups set maximum number of backups to retain. If 0, all backups are retained unless removed by MaxAge
+func MaxBackups(n int) RotateOption {
+	return func(o *rotateOptions) {
+		o.maxBackups = n
+	}
+}
+
+// MaxAge set maximum age of backups to retain, based on timestamp in backup file name.
+// If 0, backups are not removed by age
+func MaxAge(d time.Duration) RotateOption {
+	return func(o *rotateOptions) {
+		o.maxAge = d
+	}
+}
+
+// Compress set backups to be compressed with gzip
+func Compress() RotateOption {
+	return func(o *rotateOptions) {
+		o.compress = true
+	}
+}
+
+// FileMode set permission of created log file. Default is 0644
+func FileMode(perm os.FileMode) RotateOption {
+	return func(o *rotateOptions) {
+		o.perm = perm
+	}
+}
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it by size and/or time interval.
+// Rotated file is renamed with timestamp, e.g. "app-2006-01-02T15-04-05.000.log". It is safe for concurrent use
+type RotatingFile struct {
+	path    string
+	options rotateOptions
+
+	// file is nil if it is failed to be opened after rotated, then it is opened again on the next write
+	mu       sync.Mutex
+	file     *os.File
+	closed   bool
+	size     int64
+	openTime time.Time
+
+	// Backups are compressed and removed in background
+	cleanupCh   chan struct{}
+	cleanupDone chan struct{}
+	closeOnce   sync.Once
+}
+
+// NewRotatingFile opens log file at path to be appended, the directory is created if not exists
+func NewRotatingFile(path string, args ...RotateOption) (*RotatingFile, error) {
+	o := rotateOptions{perm: 0644}
+	for _, fn := range args {
+		fn(&o)
+	}
+
+	r := RotatingFile{
+		path:        path,
+		options:     o,
+		cleanupCh:   make(chan struct{}, 1),
+		cleanupDone: make(chan struct{}),
+	}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	go r.runCleanup()
+	return &r, nil
+}
+
+// Write writes p to file. File is rotated before written if it exceeds max size or interval
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.ensureOpen(); err != nil {
+		return 0, err
+	}
+
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Rotate closes current file, renames it as backup and opens a new file
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.ensureOpen(); err != nil {
+		return err
+	}
+	return r.rotate()
+}
+
+// Reopen opens the file path again and closes current file, e.g. after file is moved by external tools like logrotate.
+// Writes are blocked while the file is being reopened, so no entries are lost or duplicated. If file is failed to be
+// opened, then current file is kept
+func (r *RotatingFile) Reopen() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return os.ErrClosed
+	}
+	if r.file == nil {
+		return r.open()
+	}
+
+	f, size, err := openFile(r.path, r.options.perm)
+	if err != nil {
+		return err
+	}
+
+	prev := r.file
+	r.file = f
+	r.size = size
+	r.openTime = time.Now()
+
+	if err = prev.Close(); err != nil {
+		return fmt.Errorf("logWriter: failed to close file. Error = %w", err)
+	}
+	return nil
+}
+
+// Sync commits current content of file to stable storage
+func (r *RotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.ensureOpen(); err != nil {
+		return err
+	}
+	return r.file.Sync()
+}
+
+// Close closes file and waits until background compression and cleanup is finished
+func (r *RotatingFile) Close() error {
+	var err error
+	r.closeOnce.Do(func() {
+		r.mu.Lock()
+		r.closed = true
+		if r.file != nil {
+			err = r.file.Close()
+			r.file = nil
+		}
+		r.mu.Unlock()
+
+		close(r.cleanupCh)
+		<-r.cleanupDone
+	})
+	return err
+}
+
+func (r *RotatingFile) shouldRotate(n int64) bool {
+	if r.options.maxSize > 0 && r.size > 0 && r.size+n > r.options.maxSize {
+		return true
+	}
+	return r.options.interval > 0 && time.Since(r.openTime) >= r.options.interval
+}
+
+// ensureOpen opens file if it is failed to be opened after rotated. It returns os.ErrClosed if writer is closed.
+// Caller must hold the lock
+func (r *RotatingFile) ensureOpen() error {
+	if r.closed {
+		return os.ErrClosed
+	}
+	if r.file == nil {
+		return r.open()
+	}
+	return nil
+}
+
+// open opens file in append mode. Caller must hold the lock
+func (r *RotatingFile) open() error {
+	f, size, err := openFile(r.path, r.options.perm)
+	if err != nil {
+		return err
+	}
+
+	r.file = f
+	r.size = size
+	r.openTime = time.Now()
+	return nil
+}
+
+// openFile opens file at path in append mode and returns its size, the directory is created if not exists
+func openFile(path string, perm os.FileMode) (*os.File, int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, 0, fmt.Errorf("logWriter: failed to create directory. Error = %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, perm)
+	if err != nil {
+		return nil, 0, fmt.Errorf("logWriter: failed to open file. Error = %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, fmt.Errorf("logWriter: failed to stat file. Error = %w", err)
+	}
+	return f, info.Size(), nil
+}
+
+// rotate renames current file as backup and opens a new file. Caller must hold the lock
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("logWriter: failed to close file. Error = %w", err)
+	}
+	r.file = nil
+
+	if err := os.Rename(r.path, r.backupName(time.Now())); err != nil && !os.IsNotExist(err) {
+		// Reopen file, so writer can still be used
+		_ = r.open()
+		return fmt.Errorf("logWriter: failed to rename file. Error = %w", err)
+	}
+
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	// Trigger cleanup without blocking
+	select {
+	case r.cleanupCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// backupName returns unused backup file name for the timestamp
+func (r *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := r.nameParts()
+	name := filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+
+	// Shift timestamp if backup with the same name exists
+	for i := 0; exists(name) || exists(name+compressSuffix); i++ {
+		t = t.Add(time.Millisecond)
+		name = filepath.Join(dir, prefix+t.Format(backupTimeFormat)+ext)
+	}
+	return name
+}
+
+// nameParts returns directory, backup prefix and file extension of log file
+func (r *RotatingFile) nameParts() (string, string, string) {
+	dir := filepath.Dir(r.path)
+	base := filepath.Base(r.path)
+	ext := filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+func (r *RotatingFile) runCleanup() {
+	defer close(r.cleanupDone)
+	for range r.cleanupCh {
+		r.cleanup()
+	}
+}
+
+type backupFile struct {
+	path      string
+	timestamp time.Time
+}
+
+// cleanup compresses backups and removes backups that exceed max backups or max age
+func (r *RotatingFile) cleanup() {
+	backups := r.backups()
+
+	// Remove old backups
+	var cutoff time.Time
+	if r.options.maxAge > 0 {
+		cutoff = time.Now().Add(-r.options.maxAge)
+	}
+
+	var retained []backupFile
+	for i, b := range backups {
+		if (r.options.maxBackups > 0 && i >= r.options.maxBackups) || (!cutoff.IsZero() && b.timestamp.Before(cutoff)) {
+			_ = os.Remove(b.path)
+			continue
+		}
+		retained = append(retained, b)
+	}
+
+	if !r.options.compress {
+		return
+	}
+
+	for _, b := range retained {
+		if strings.HasSuffix(b.path, compressSuffix) {
+			continue
+		}
+		_ = compressFile(b.path, r.options.perm)
+	}
+}
+
+// backups returns backup files sorted from the newest
+func (r *RotatingFile) backups() []backupFile {
+	dir, prefix, ext := r.nameParts()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var backups []backupFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		ts := strings.TrimPrefix(strings.TrimSuffix(name, compressSuffix), prefix)
+		if !strings.HasSuffix(ts, ext) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(backupTimeFormat, strings.TrimSuffix(ts, ext), time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), timestamp: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+	return backups
+}
+
+// compressFile compresses file with gzip to a file with perm permission and removes the source file
+func compressFile(path string, perm os.FileMode) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path + compressSuffix)
+		return err
+	}
+	return os.Remove(path)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
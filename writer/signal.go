@@ -0,0 +1,51 @@
+package logWriter
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Reopener is a writer that can reopen its underlying file
+type Reopener interface {
+	Reopen() error
+}
+
+// ReopenOnSignal reopens writers each time one of signals is received. If no signals is set, SIGHUP is used.
+// Error on reopen is passed to onError if set. Call returned function to stop listening to signals
+func ReopenOnSignal(writers []Reopener, onError func(error), sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case <-ch:
+				for _, w := range writers {
+					if err := w.Reopen(); err != nil && onError != nil {
+						onError(err)
+					}
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+			<-exited
+		})
+	}
+}
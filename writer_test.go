@@ -0,0 +1,353 @@
+package nlogger_test
+
+import (
+	"compress/gzip"
+	"github.com/nbs-go/nlogger/v2"
+	logConfig "github.com/nbs-go/nlogger/v2/config"
+	"github.com/nbs-go/nlogger/v2/level"
+	logOption "github.com/nbs-go/nlogger/v2/option"
+	logWriter "github.com/nbs-go/nlogger/v2/writer"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestRotatingFile_MaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.MaxSize(10))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n"} {
+		if _, err = w.Write([]byte(line)); err != nil {
+			t.Fatalf("unexpected error = %s", err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	names := listDir(t, dir)
+	if len(names) != 3 {
+		t.Fatalf("unexpected files = %v", names)
+	}
+
+	b, _ := ioutil.ReadFile(path)
+	if string(b) != "line-3\n" {
+		t.Errorf("unexpected current content = %q", b)
+	}
+
+	for _, n := range names {
+		if n != "app.log" && (!strings.HasPrefix(n, "app-") || !strings.HasSuffix(n, ".log")) {
+			t.Errorf("unexpected backup name = %s", n)
+		}
+	}
+}
+
+func TestRotatingFile_MaxBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.MaxBackups(2))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		_, _ = w.Write([]byte("entry\n"))
+		if err = w.Rotate(); err != nil {
+			t.Fatalf("unexpected error = %s", err)
+		}
+	}
+	_ = w.Close()
+
+	if names := listDir(t, dir); len(names) != 3 {
+		t.Errorf("expected current file and 2 backups, got %v", names)
+	}
+}
+
+func TestRotatingFile_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	// Create an expired backup
+	old := filepath.Join(dir, "app-"+time.Now().Add(-48*time.Hour).Format("2006-01-02T15-04-05.000")+".log")
+	if err := ioutil.WriteFile(old, []byte("old\n"), 0644); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	// Unrelated file must not be removed
+	other := filepath.Join(dir, "other.txt")
+	_ = ioutil.WriteFile(other, []byte("other\n"), 0644)
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.MaxAge(24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	_, _ = w.Write([]byte("entry\n"))
+	_ = w.Rotate()
+	_ = w.Close()
+
+	if _, err = os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("expected expired backup is removed")
+	}
+	if _, err = os.Stat(other); err != nil {
+		t.Errorf("expected unrelated file is retained")
+	}
+	if names := listDir(t, dir); len(names) != 3 {
+		t.Errorf("unexpected files = %v", names)
+	}
+}
+
+func TestRotatingFile_Compress(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.Compress())
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	_, _ = w.Write([]byte("compressed\n"))
+	_ = w.Rotate()
+	_ = w.Close()
+
+	var gzName string
+	for _, n := range listDir(t, dir) {
+		if strings.HasSuffix(n, ".log.gz") {
+			gzName = n
+		} else if n != "app.log" {
+			t.Errorf("unexpected file = %s", n)
+		}
+	}
+	if gzName == "" {
+		t.Fatalf("compressed backup not found")
+	}
+
+	f, err := os.Open(filepath.Join(dir, gzName))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	b, _ := ioutil.ReadAll(gz)
+	if string(b) != "compressed\n" {
+		t.Errorf("unexpected backup content = %q", b)
+	}
+}
+
+func TestRotatingFile_CompressFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permission is not supported on windows")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.Compress(), logWriter.FileMode(0600))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	_, _ = w.Write([]byte("compressed\n"))
+	_ = w.Rotate()
+	_ = w.Close()
+
+	names := listDir(t, dir)
+	if len(names) != 2 {
+		t.Fatalf("unexpected files = %v", names)
+	}
+	for _, n := range names {
+		info, err := os.Stat(filepath.Join(dir, n))
+		if err != nil {
+			t.Fatalf("unexpected error = %s", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("unexpected permission of %s = %o", n, perm)
+		}
+	}
+}
+
+func TestRotatingFile_Interval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.Interval(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	_, _ = w.Write([]byte("first\n"))
+	time.Sleep(30 * time.Millisecond)
+	_, _ = w.Write([]byte("second\n"))
+	_ = w.Close()
+
+	if names := listDir(t, dir); len(names) != 2 {
+		t.Errorf("unexpected files = %v", names)
+	}
+}
+
+func TestRotatingFile_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	w, err := logWriter.NewRotatingFile(path, logWriter.MaxSize(100))
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+
+	line := "0123456789\n"
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, _ = w.Write([]byte(line))
+			}
+		}()
+	}
+	wg.Wait()
+	_ = w.Close()
+
+	total := 0
+	for _, n := range listDir(t, dir) {
+		b, _ := ioutil.ReadFile(filepath.Join(dir, n))
+		if len(b) > 100 {
+			t.Errorf("file %s exceeds max size: %d", n, len(b))
+		}
+		for _, l := range strings.SplitAfter(string(b), "\n") {
+			if l != "" && l != line {
+				t.Errorf("unexpected interleaved line = %q", l)
+			}
+		}
+		total += len(b)
+	}
+	if total != 8*50*len(line) {
+		t.Errorf("unexpected total written = %d", total)
+	}
+
+	if _, err = w.Write([]byte(line)); err == nil {
+		t.Errorf("expected error on write after close")
+	}
+}
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	moved := filepath.Join(dir, "app.log.1")
+
+	w, err := logWriter.NewRotatingFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer w.Close()
+
+	l := nlogger.NewStdLogger(nlogger.NewStdLogPrinter(w, 0), logOption.Level(level.Info))
+	l.Info("before")
+
+	// Simulate logrotate with create mode
+	if err = os.Rename(path, moved); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	l.Info("during")
+	if err = w.Reopen(); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	l.Info("after")
+
+	b, _ := ioutil.ReadFile(moved)
+	if s := string(b); !strings.Contains(s, "before") || !strings.Contains(s, "during") || strings.Contains(s, "after") {
+		t.Errorf("unexpected moved file content = %q", s)
+	}
+
+	b, _ = ioutil.ReadFile(path)
+	if s := string(b); !strings.Contains(s, "after") || strings.Contains(s, "during") {
+		t.Errorf("unexpected reopened file content = %q", s)
+	}
+}
+
+func TestRotatingFile_ReopenError(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	moved := filepath.Join(dir, "app.log.1")
+
+	w, err := logWriter.NewRotatingFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer w.Close()
+
+	// Replace file path with a directory, so file cannot be reopened
+	if err = os.Rename(path, moved); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if err = os.Mkdir(path, 0755); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if err = w.Reopen(); err == nil {
+		t.Fatalf("expected error when reopening file")
+	}
+
+	// Current file must be kept
+	if _, err = w.Write([]byte("kept\n")); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	if b, _ := ioutil.ReadFile(moved); string(b) != "kept\n" {
+		t.Errorf("unexpected moved file content = %q", b)
+	}
+
+	// Reopen again after path is fixed
+	_ = os.Remove(path)
+	if err = w.Reopen(); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	_, _ = w.Write([]byte("reopened\n"))
+	if b, _ := ioutil.ReadFile(path); string(b) != "reopened\n" {
+		t.Errorf("unexpected reopened file content = %q", b)
+	}
+}
+
+func TestConfig_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	l, err := logConfig.New(&logConfig.Config{Level: "info", Printer: logConfig.PrinterJSON, Outputs: []string{path}})
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer l.Close()
+
+	l.Info("before")
+	_ = os.Rename(path, path+".1")
+	if err = l.Reopen(); err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	l.Info("after")
+
+	b, _ := ioutil.ReadFile(path)
+	if s := string(b); !strings.Contains(s, "after") || strings.Contains(s, "before") {
+		t.Errorf("unexpected reopened file content = %q", s)
+	}
+}
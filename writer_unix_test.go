@@ -0,0 +1,48 @@
+//go:build !windows
+// +build !windows
+
+package nlogger_test
+
+import (
+	logWriter "github.com/nbs-go/nlogger/v2/writer"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReopenOnSignal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	moved := filepath.Join(dir, "app.log.1")
+
+	w, err := logWriter.NewRotatingFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error = %s", err)
+	}
+	defer w.Close()
+
+	stop := logWriter.ReopenOnSignal([]logWriter.Reopener{w}, func(err error) {
+		t.Errorf("unexpected error = %s", err)
+	}, syscall.SIGUSR1)
+	defer stop()
+
+	_ = os.Rename(path, moved)
+
+	p, _ := os.FindProcess(os.Getpid())
+	if err = p.Signal(syscall.SIGUSR1); err != nil {
+		t.Skipf("signal is not supported. Error = %s", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err = os.Stat(path); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("file is not reopened on signal")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}